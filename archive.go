@@ -0,0 +1,133 @@
+//go:build gonfig_archive
+
+package gonfig
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// zipMagic is the header of zip archives.
+var zipMagic = []byte("PK\x03\x04")
+
+// ReadConfigFromArchive reads the configuration file entryName from the zip
+// or tar archive at archivePath, e.g. from a distributed bundle, unmarshals
+// its content into the given configuration object, and validates it. Tar
+// archives may be gzip-compressed. The format is detected from the content
+// of the archive. Entry names are compared after cleaning them, so that
+// "./config.toml" matches "config.toml".
+//
+// Returns an error naming the entry if the archive does not contain it.
+// Errors refer to the entry as archivePath:entryName. Only available with the
+// gonfig_archive build tag.
+func ReadConfigFromArchive[T any](archivePath, entryName string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	o := newOptions(opts)
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration archive %s: %w", archivePath, err)
+	}
+	defer f.Close()
+
+	content, err := readArchiveEntry(f, cleanEntryName(entryName), o)
+	if errors.Is(err, errEntryNotFound) {
+		return fmt.Errorf("configuration archive %s does not contain %s", archivePath, entryName)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read %s from configuration archive %s: %w", entryName, archivePath, err)
+	}
+
+	return processConfig(archivePath+":"+entryName, content, c, unmarshal, finalize, o)
+}
+
+// errEntryNotFound is returned by readArchiveEntry if the entry does not
+// exist.
+var errEntryNotFound = errors.New("entry not found")
+
+// readArchiveEntry returns the content of the entry name of the archive f.
+func readArchiveEntry(f *os.File, name string, o *options) ([]byte, error) {
+	magic := make([]byte, len(zipMagic))
+
+	_, err := io.ReadFull(f, magic)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	if bytes.Equal(magic, zipMagic) {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+
+		zr, err := zip.NewReader(f, info.Size())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range zr.File {
+			if cleanEntryName(entry.Name) != name {
+				continue
+			}
+
+			r, err := entry.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+
+			return readAll(r, o)
+		}
+
+		return nil, errEntryNotFound
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(f)
+
+	var r io.Reader = br
+
+	header, err := br.Peek(len(gzipMagic))
+	if err == nil && bytes.Equal(header, gzipMagic) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errEntryNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag == tar.TypeReg && cleanEntryName(hdr.Name) == name {
+			return readAll(tr, o)
+		}
+	}
+}
+
+// cleanEntryName returns the canonical form of the archive entry name.
+func cleanEntryName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
@@ -0,0 +1,157 @@
+//go:build gonfig_archive
+
+package gonfig
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// archiveEntries are the entries of the generated test archives.
+var archiveEntries = map[string]string{
+	"README":             "not a configuration file",
+	"./etc/config.json":  `{"Name": "bundle", "Port": 8080}`,
+	"etc/malformed.json": `{`,
+}
+
+// writeZip writes a zip archive of archiveEntries to dir and returns its path.
+func writeZip(t *testing.T, dir string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	for name, content := range archiveEntries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = w.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return writeFile(t, dir, "bundle.zip", buf.String())
+}
+
+// writeTar writes a tar archive of archiveEntries, gzip-compressed if
+// compress is true, to dir and returns its path.
+func writeTar(t *testing.T, dir string, compress bool) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	var (
+		w  io.Writer = &buf
+		zw *gzip.Writer
+	)
+
+	if compress {
+		zw = gzip.NewWriter(&buf)
+		w = zw
+	}
+
+	tw := tar.NewWriter(w)
+
+	err := tw.WriteHeader(&tar.Header{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0o755})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range archiveEntries {
+		err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tw.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "bundle.tar"
+
+	if compress {
+		err = zw.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name += ".gz"
+	}
+
+	return writeFile(t, dir, name, buf.String())
+}
+
+func TestReadConfigFromArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	archives := map[string]string{
+		"zip":    writeZip(t, dir),
+		"tar":    writeTar(t, dir, false),
+		"tar.gz": writeTar(t, dir, true),
+	}
+
+	for name, archive := range archives {
+		t.Run(name, func(t *testing.T) {
+			var c testConfig
+
+			err := ReadConfigFromArchive(archive, "etc/config.json", &c, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := (testConfig{Name: "bundle", Port: 8080}); c != want {
+				t.Errorf("got %+v, want %+v", c, want)
+			}
+
+			err = ReadConfigFromArchive(archive, "etc/missing.json", &c, JSON[testConfig](), nil)
+			if err == nil || !strings.Contains(err.Error(), "does not contain etc/missing.json") {
+				t.Errorf("got error %v, want one naming the missing entry", err)
+			}
+
+			err = ReadConfigFromArchive(archive, "etc/malformed.json", &c, JSON[testConfig](), nil)
+			if err == nil || !strings.Contains(err.Error(), archive+":etc/malformed.json") {
+				t.Errorf("got error %v, want one naming the archive entry", err)
+			}
+		})
+	}
+}
+
+func TestReadConfigFromArchiveErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	err := ReadConfigFromArchive(filepath.Join(dir, "missing.zip"), "config.json", &testConfig{}, JSON[testConfig](), nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v, want it to match os.ErrNotExist", err)
+	}
+
+	archive := writeZip(t, dir)
+
+	err = ReadConfigFromArchive(archive, "etc/config.json", &testConfig{}, JSON[testConfig](), nil, WithMaxSize(8))
+	if err == nil || !strings.Contains(err.Error(), "maximum size") {
+		t.Errorf("got error %v, want the maximum size to be exceeded", err)
+	}
+}
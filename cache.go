@@ -0,0 +1,76 @@
+package gonfig
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedFinder memoizes the results of FindConfig for a limited time, saving
+// repeated stat calls when the same configuration file is resolved often. It
+// is safe for concurrent use.
+//
+// Only successful resolutions are cached, so that a configuration file that is
+// created after a failed lookup is picked up by the next one. The zero value
+// is ready to use, but with a time to live of zero it caches nothing; use
+// NewCachedFinder to set one.
+type CachedFinder struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cachedPath
+}
+
+// cachedPath is a resolved path cached by CachedFinder.
+type cachedPath struct {
+	path    string
+	expires time.Time
+}
+
+// NewCachedFinder returns a CachedFinder caching resolved paths for ttl.
+func NewCachedFinder(ttl time.Duration) *CachedFinder {
+	return &CachedFinder{
+		ttl:     ttl,
+		entries: map[string]cachedPath{},
+	}
+}
+
+// FindConfig is like the package-level FindConfig, but returns a cached
+// result for the same primary path and fallback paths if it has not expired
+// yet.
+func (f *CachedFinder) FindConfig(path string, paths []string) (string, error) {
+	key := path + "\x00" + strings.Join(paths, "\x00")
+	now := time.Now()
+
+	f.mu.Lock()
+	entry, ok := f.entries[key]
+	f.mu.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		return entry.path, nil
+	}
+
+	resolved, err := FindConfig(path, paths)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	if f.entries == nil {
+		f.entries = map[string]cachedPath{}
+	}
+	f.entries[key] = cachedPath{
+		path:    resolved,
+		expires: now.Add(f.ttl),
+	}
+	f.mu.Unlock()
+
+	return resolved, nil
+}
+
+// Invalidate removes all cached results.
+func (f *CachedFinder) Invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	clear(f.entries)
+}
@@ -0,0 +1,142 @@
+package gonfig
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCachedFinderZeroValue(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{}`)
+
+	var f CachedFinder
+
+	got, err := f.FindConfig(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != path {
+		t.Errorf("got %q, want %q", got, path)
+	}
+
+	f.Invalidate()
+}
+
+func TestCachedFinderCaches(t *testing.T) {
+	dir := t.TempDir()
+	first := writeFile(t, dir, "first.json", `{}`)
+	second := filepath.Join(dir, "second.json")
+
+	f := NewCachedFinder(time.Hour)
+
+	got, err := f.FindConfig("", []string{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != first {
+		t.Fatalf("got %q, want %q", got, first)
+	}
+
+	writeFile(t, dir, "second.json", `{}`)
+
+	got, err = f.FindConfig("", []string{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != first {
+		t.Errorf("got %q, want the cached %q", got, first)
+	}
+
+	f.Invalidate()
+
+	got, err = f.FindConfig("", []string{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != second {
+		t.Errorf("got %q after invalidation, want %q", got, second)
+	}
+}
+
+func TestCachedFinderExpires(t *testing.T) {
+	dir := t.TempDir()
+	first := writeFile(t, dir, "first.json", `{}`)
+	second := filepath.Join(dir, "second.json")
+
+	f := NewCachedFinder(10 * time.Millisecond)
+
+	_, err := f.FindConfig("", []string{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, dir, "second.json", `{}`)
+	time.Sleep(20 * time.Millisecond)
+
+	got, err := f.FindConfig("", []string{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != second {
+		t.Errorf("got %q after expiry, want %q", got, second)
+	}
+}
+
+func TestCachedFinderSkipsErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	f := NewCachedFinder(time.Hour)
+
+	_, err := f.FindConfig("", []string{path})
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Fatalf("got error %v, want ErrConfigNotFound", err)
+	}
+
+	writeFile(t, dir, "config.json", `{}`)
+
+	got, err := f.FindConfig("", []string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != path {
+		t.Errorf("got %q, want the newly created %q", got, path)
+	}
+}
+
+func TestCachedFinderConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{
+		writeFile(t, dir, "a.json", `{}`),
+		writeFile(t, dir, "b.json", `{}`),
+	}
+
+	f := NewCachedFinder(time.Millisecond)
+
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range 100 {
+				path := paths[(i+j)%len(paths)]
+
+				got, err := f.FindConfig(path, nil)
+				if err != nil || got != path {
+					t.Errorf("got %q and error %v, want %q", got, err, path)
+					return
+				}
+
+				if j%10 == 0 {
+					f.Invalidate()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,43 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReadConfigCandidates is like ReadConfig, but tries each of the given
+// unmarshal functions in order until one of them succeeds. This is useful for
+// configuration files whose format cannot be told from their extension.
+//
+// An unmarshal function only succeeds if the configuration object it produces
+// also passes validation, so that a lenient decoder producing an empty object
+// does not mask a better match. Each attempt starts from a zero value of type
+// T, which replaces *c on success. Returns the error of the last attempt if
+// all of them fail.
+func ReadConfigCandidates[T any](path string, searchPaths []string, c *T, unmarshalers []UnmarshalFunc[*T], finalize FinalizeFunc[*T]) (string, error) {
+	var err error
+
+	path, err = FindConfig(path, searchPaths)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := readFile(path, nil)
+	if err != nil {
+		return path, fmt.Errorf("unable to read configuration file %s: %w", path, err)
+	}
+
+	err = errors.New("no unmarshal functions given")
+
+	for _, unmarshal := range unmarshalers {
+		var attempt T
+
+		err = processConfig(path, content, &attempt, unmarshal, finalize, nil)
+		if err == nil {
+			*c = attempt
+			return path, nil
+		}
+	}
+
+	return path, err
+}
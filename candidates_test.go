@@ -0,0 +1,59 @@
+package gonfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigCandidates(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config", `{"Name": "app"}`)
+
+	// lenient accepts any content without setting any field.
+	lenient := func([]byte, *testConfig) error { return nil }
+
+	requireName := func(c *testConfig) error {
+		if c.Name == "" {
+			return errors.New("name is required")
+		}
+
+		return nil
+	}
+
+	t.Run("validation picks the better match", func(t *testing.T) {
+		var c testConfig
+
+		_, err := ReadConfigCandidates(path, nil, &c, []UnmarshalFunc[*testConfig]{lenient, JSON[testConfig]()}, requireName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Name != "app" {
+			t.Errorf("got name %q, want app", c.Name)
+		}
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		c := testConfig{Name: "initial"}
+
+		failing := func([]byte, *testConfig) error { return errors.New("last decoder failed") }
+
+		_, err := ReadConfigCandidates(path, nil, &c, []UnmarshalFunc[*testConfig]{lenient, failing}, requireName)
+		if err == nil || !strings.Contains(err.Error(), "last decoder failed") {
+			t.Errorf("got error %v, want the error of the last attempt", err)
+		}
+
+		if c.Name != "initial" {
+			t.Errorf("got name %q, want the configuration object untouched", c.Name)
+		}
+	})
+
+	t.Run("none given", func(t *testing.T) {
+		var c testConfig
+
+		_, err := ReadConfigCandidates(path, nil, &c, nil, nil)
+		if err == nil {
+			t.Error("got no error without unmarshal functions")
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LoadCapture is a serializable record of everything that influenced loading
+// a configuration as captured by CaptureLoad, e.g. to attach to a bug report
+// about a configuration issue that only occurs on one machine. ReplayLoad
+// reconstructs the configuration object from it.
+type LoadCapture struct {
+	// Path is the resolved path of the configuration file.
+	Path string `json:"path"`
+	// Content is the raw content of the configuration file.
+	Content []byte `json:"content"`
+	// LocalOverride is the raw content of the local override file read
+	// with WithLocalOverride, or nil if there is none.
+	LocalOverride []byte `json:"local_override,omitempty"`
+	// EnvPrefix is the prefix passed to ApplyEnv.
+	EnvPrefix string `json:"env_prefix,omitempty"`
+	// Env holds the environment variables consulted by ApplyEnv that were
+	// set, as listed by EnvVars.
+	Env map[string]string `json:"env,omitempty"`
+	// Config is the resulting configuration object encoded as JSON, or
+	// empty if loading failed.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// CaptureLoad is like ReadConfig followed by ApplyEnv with envPrefix, but
+// also records the resolved path, the raw content, the consulted environment
+// variables, and the resulting configuration object in the returned capture.
+// Environment variables are applied after unmarshaling and before finalize
+// runs.
+//
+// The capture is returned along with the error if the configuration file was
+// read but could not be unmarshaled or validated, so that failing loads can
+// be captured as well. Note that the capture may contain secrets.
+func CaptureLoad[T any](path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], envPrefix string, opts ...Option) (LoadCapture, error) {
+	o := newOptions(opts)
+
+	path, err := FindConfig(path, searchPaths, opts...)
+	if err != nil {
+		return LoadCapture{}, err
+	}
+
+	content, err := readFoundContent(path, o)
+	if err != nil {
+		return LoadCapture{}, err
+	}
+
+	capture := LoadCapture{
+		Path:      path,
+		Content:   content,
+		EnvPrefix: envPrefix,
+	}
+
+	if o.localOverride {
+		capture.LocalOverride, err = readLocalOverride(LocalOverridePath(path), o)
+		if err != nil {
+			return LoadCapture{}, err
+		}
+	}
+
+	for _, name := range EnvVars[T](envPrefix) {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if capture.Env == nil {
+			capture.Env = map[string]string{}
+		}
+
+		capture.Env[name] = value
+	}
+
+	err = replayLoad(&capture, c, unmarshal, finalize, o)
+	if err != nil {
+		return capture, err
+	}
+
+	capture.Config, err = json.Marshal(c)
+	if err != nil {
+		return capture, fmt.Errorf("unable to encode configuration: %w", err)
+	}
+
+	return capture, nil
+}
+
+// ReplayLoad reconstructs the configuration object from capture by
+// unmarshaling the captured content, applying the captured environment
+// variables, and validating the result, without touching the file system or
+// the environment. The captured local override file, if any, is used instead
+// of reading it with WithLocalOverride. Passing the same functions and
+// options as to CaptureLoad yields the same configuration object, which can
+// be checked against capture.Config.
+func ReplayLoad[T any](capture LoadCapture, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	return replayLoad(&capture, c, unmarshal, finalize, newOptions(opts))
+}
+
+// replayLoad processes the content of capture using its environment
+// variables.
+func replayLoad[T any](capture *LoadCapture, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], o *options) error {
+	lookup := func(name string) (string, bool) {
+		value, ok := capture.Env[name]
+		return value, ok
+	}
+
+	replay := *o
+	replay.localFile = true
+	replay.readLocal = func(string, *options) ([]byte, error) {
+		return capture.LocalOverride, nil
+	}
+
+	return processConfig(capture.Path, capture.Content, c, unmarshal, func(c *T) error {
+		err := applyEnv(c, capture.EnvPrefix, lookup)
+		if err != nil {
+			return err
+		}
+
+		return finalizeConfig(c, finalize)
+	}, &replay)
+}
@@ -0,0 +1,145 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCaptureLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Database":{"Port":5432}}`)
+
+	t.Setenv("APP_NAME", "env")
+	t.Setenv("DATABASE_URL", "postgres://db")
+	t.Setenv("APP_UNRELATED", "x")
+
+	var c envConfig
+
+	capture, err := CaptureLoad("", []string{filepath.Join(dir, "missing.json"), path}, &c, JSON[envConfig](), nil, "APP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if capture.Path != path || string(capture.Content) != `{"Database":{"Port":5432}}` {
+		t.Errorf("got path %q and content %q", capture.Path, capture.Content)
+	}
+
+	if want := map[string]string{"APP_NAME": "env", "DATABASE_URL": "postgres://db"}; !reflect.DeepEqual(capture.Env, want) {
+		t.Errorf("got env %q, want %q", capture.Env, want)
+	}
+
+	if c.Name != "env" || c.Database.URL != "postgres://db" || c.Database.Port != 5432 {
+		t.Errorf("got %+v", c)
+	}
+
+	encoded, err := json.Marshal(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded LoadCapture
+
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replaying must not depend on the real file or environment.
+	writeFile(t, dir, "config.json", `{"Name":"changed"}`)
+	t.Setenv("APP_NAME", "changed")
+
+	var replayed envConfig
+
+	err = ReplayLoad(decoded, &replayed, JSON[envConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(replayed, c) {
+		t.Errorf("got %+v, want %+v", replayed, c)
+	}
+
+	config, err := json.Marshal(replayed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(config) != string(decoded.Config) {
+		t.Errorf("got config %s, want the captured %s", config, decoded.Config)
+	}
+}
+
+func TestCaptureLoadFailure(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":`)
+
+	var c testConfig
+
+	capture, err := CaptureLoad(path, nil, &c, JSON[testConfig](), nil, "")
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	if capture.Path != path || string(capture.Content) != `{"Name":` || capture.Config != nil {
+		t.Errorf("got %+v, want the failing content captured without a configuration", capture)
+	}
+}
+
+func TestReplayLoadLocalOverride(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name":"file","Port":1}`)
+	local := writeFile(t, dir, "config.local.json", `{"Port":2}`)
+
+	var c testConfig
+
+	capture, err := CaptureLoad(path, nil, &c, JSON[testConfig](), nil, "", WithLocalOverride())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Port != 2 {
+		t.Fatalf("got port %d, want 2 from the local override", c.Port)
+	}
+
+	encoded, err := json.Marshal(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded LoadCapture
+
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replaying must use the captured override, not the file.
+	writeFile(t, dir, filepath.Base(local), `{"Port":3}`)
+
+	var replayed testConfig
+
+	err = ReplayLoad(decoded, &replayed, JSON[testConfig](), nil, WithLocalOverride())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(replayed, c) {
+		t.Errorf("got %+v, want %+v", replayed, c)
+	}
+
+	err = os.Remove(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ReplayLoad(decoded, &replayed, JSON[testConfig](), nil, WithLocalOverride())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replayed.Port != 2 {
+		t.Errorf("got port %d, want 2", replayed.Port)
+	}
+}
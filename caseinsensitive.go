@@ -0,0 +1,71 @@
+package gonfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindConfigCI is like FindConfig, but matches the base names of the primary
+// path and the fallback paths case-insensitively against the entries of their
+// directories, e.g. a fallback path config.toml matches a file named
+// Config.TOML. Directory names are still matched as is by the file system.
+//
+// An exact match takes precedence over case variants. Among multiple case
+// variants, the first one in lexical order is chosen. Glob patterns are not
+// matched case-insensitively.
+func FindConfigCI(path string, paths []string) (string, error) {
+	if path != "" {
+		return FindConfig(resolveCI(path), nil)
+	}
+
+	resolved := make([]string, len(paths))
+	for i, p := range paths {
+		if isGlob(p) {
+			resolved[i] = p
+			continue
+		}
+
+		resolved[i] = resolveCI(p)
+	}
+
+	path, err := FindConfig("", resolved)
+	if nf, ok := err.(*NotFoundError); ok {
+		nf.Paths = paths
+	}
+
+	return path, err
+}
+
+// resolveCI returns the path of the entry in the directory of path whose name
+// matches the base name of path case-insensitively, or path itself if there
+// is no such entry.
+func resolveCI(path string) string {
+	dir, base := filepath.Split(path)
+
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return path
+	}
+
+	match := ""
+
+	// The entries are sorted by name.
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if name == base {
+			return path
+		}
+
+		if match == "" && strings.EqualFold(name, base) {
+			match = name
+		}
+	}
+
+	if match == "" {
+		return path
+	}
+
+	return dir + match
+}
@@ -0,0 +1,75 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindConfigCI(t *testing.T) {
+	dir := t.TempDir()
+	mixed := writeFile(t, dir, "Config.TOML", ``)
+
+	got, err := FindConfigCI("", []string{filepath.Join(dir, "missing.toml"), filepath.Join(dir, "config.toml")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != mixed {
+		t.Errorf("got path %q, want %q", got, mixed)
+	}
+
+	got, err = FindConfigCI(filepath.Join(dir, "CONFIG.toml"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filepath.Base(got) != "Config.TOML" && filepath.Base(got) != "CONFIG.toml" {
+		t.Errorf("got path %q, want a case variant of config.toml", got)
+	}
+
+	_, err = FindConfigCI("", []string{filepath.Join(dir, "other.toml")})
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound", err)
+	}
+}
+
+func TestFindConfigCIVariants(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "config.TOML", ``)
+	first := writeFile(t, dir, "Config.toml", ``)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Skip("the file system is case-insensitive")
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		got, err := FindConfigCI("", []string{filepath.Join(dir, "CONFIG.TOML")})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != first {
+			t.Errorf("got path %q, want the lexically first variant %q", got, first)
+		}
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		exact := writeFile(t, dir, "config.toml", ``)
+
+		got, err := FindConfigCI("", []string{exact})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != exact {
+			t.Errorf("got path %q, want the exact match %q", got, exact)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package gonfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ChecksumMatch returns a FinalizeFunc that checks whether the SHA-256 hash
+// of the file whose path is returned by path matches the hex-encoded checksum
+// returned by sum, e.g. to detect tampering with a policy bundle referenced
+// by the configuration. The checksum is compared case-insensitively and may
+// carry a sha256: prefix. Since this reads the file, it must be opted into
+// explicitly. The check is skipped if both the path and the checksum are
+// empty.
+func ChecksumMatch[T any](path, sum func(T) string) FinalizeFunc[T] {
+	return func(c T) error {
+		name, want := path(c), strings.TrimPrefix(strings.ToLower(sum(c)), "sha256:")
+		if name == "" && want == "" {
+			return nil
+		}
+
+		if want == "" {
+			return fmt.Errorf("no checksum declared for file %s", name)
+		}
+
+		f, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("unable to verify checksum of file %s: %w", name, err)
+		}
+		defer f.Close()
+
+		h := sha256.New()
+
+		_, err = io.Copy(h, f)
+		if err != nil {
+			return fmt.Errorf("unable to verify checksum of file %s: %w", name, err)
+		}
+
+		got := hex.EncodeToString(h.Sum(nil))
+		if got != want {
+			return fmt.Errorf("checksum of file %s is %s instead of %s", name, got, want)
+		}
+
+		return nil
+	}
+}
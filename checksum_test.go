@@ -0,0 +1,63 @@
+package gonfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChecksumMatch(t *testing.T) {
+	type config struct {
+		Policy       string
+		PolicySHA256 string
+	}
+
+	dir := t.TempDir()
+	policy := writeFile(t, dir, "policy.rego", "package authz\n")
+
+	h := sha256.Sum256([]byte("package authz\n"))
+	sum := hex.EncodeToString(h[:])
+
+	validate := ChecksumMatch(
+		func(c *config) string { return c.Policy },
+		func(c *config) string { return c.PolicySHA256 },
+	)
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr string
+	}{
+		{"matching", config{Policy: policy, PolicySHA256: sum}, ""},
+		{"prefixed upper case", config{Policy: policy, PolicySHA256: "sha256:" + strings.ToUpper(sum)}, ""},
+		{"unset", config{}, ""},
+		{"mismatching", config{Policy: policy, PolicySHA256: strings.Repeat("0", 64)}, "checksum of file " + policy + " is " + sum},
+		{"undeclared", config{Policy: policy}, "no checksum declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.c)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := validate(&config{Policy: filepath.Join(dir, "missing.rego"), PolicySHA256: sum})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got error %v, want fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,140 @@
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReadConfigContext is like ReadConfig, but stops waiting for the
+// configuration file to be located and read once ctx is done. All options of
+// ReadConfig are supported, and StdinPath reads from standard input.
+//
+// This bounds the time spent on slow or hanging file systems. Note that the
+// underlying read cannot be interrupted and keeps running in the background
+// until it returns on its own. c is only updated if the configuration is read
+// and validated before ctx is done.
+func ReadConfigContext[T any](ctx context.Context, path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, error) {
+	return runContext(ctx, c, func(next *T) (string, error) {
+		return ReadConfig(path, searchPaths, next, unmarshal, finalize, opts...)
+	})
+}
+
+// ReadFoundConfigContext is like ReadFoundConfig, but stops waiting for the
+// configuration file to be read once ctx is done.
+func ReadFoundConfigContext[T any](ctx context.Context, path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	_, err := runContext(ctx, c, func(next *T) (string, error) {
+		return path, ReadFoundConfig(path, next, unmarshal, finalize, opts...)
+	})
+
+	return err
+}
+
+// runContext calls read with a copy of *c in a separate goroutine and stores
+// the copy in *c if read succeeds before ctx is done. Returns the
+// result of read, or the error of ctx if ctx is done first.
+func runContext[T any](ctx context.Context, c *T, read func(*T) (string, error)) (string, error) {
+	err := ctx.Err()
+	if err != nil {
+		return "", err
+	}
+
+	type result struct {
+		path  string
+		value T
+		err   error
+	}
+
+	ch := make(chan result, 1)
+
+	go func(r result) {
+		r.path, r.err = read(&r.value)
+		ch <- r
+	}(result{value: *c})
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-ch:
+		if r.err != nil {
+			return r.path, r.err
+		}
+
+		*c = r.value
+
+		return r.path, nil
+	}
+}
+
+// ReadConfigReaderContext is like ReadConfigReader, but stops waiting for r
+// to be read once ctx is done.
+func ReadConfigReaderContext[T any](ctx context.Context, r io.Reader, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	content, err := readContext(ctx, func() ([]byte, error) {
+		return readAll(r, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read configuration: %w", err)
+	}
+
+	return processConfig("", content, c, unmarshal, finalize, nil)
+}
+
+// readContext calls read in a separate goroutine and returns its result, or
+// the error of ctx if ctx is done first.
+func readContext(ctx context.Context, read func() ([]byte, error)) ([]byte, error) {
+	err := ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		content []byte
+		err     error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		content, err := read()
+		ch <- result{content, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		return r.content, r.err
+	}
+}
+
+// ReadConfigTimeout is like ReadConfig, but bounds the whole pipeline,
+// including locating, reading, and validating the configuration file, by the
+// timeout d. The context carrying the deadline is passed to finalize, which
+// returns the FinalizeFunc to use, so that validators doing I/O, such as
+// Reachable, can honor it. finalize may be nil.
+//
+// If the deadline is exceeded, the returned error wraps
+// context.DeadlineExceeded, which distinguishes a timeout from a validation
+// failure, and c is left untouched. As with ReadConfigContext, stages that
+// do not honor the context keep running in the background until they return
+// on their own.
+func ReadConfigTimeout[T any](d time.Duration, path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize func(context.Context) FinalizeFunc[*T], opts ...Option) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	var validate FinalizeFunc[*T]
+	if finalize != nil {
+		validate = finalize(ctx)
+	}
+
+	found, err := runContext(ctx, c, func(next *T) (string, error) {
+		return ReadConfig(path, searchPaths, next, unmarshal, validate, opts...)
+	})
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return found, fmt.Errorf("unable to read configuration within %s: %w", d, err)
+	}
+
+	return found, err
+}
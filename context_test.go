@@ -0,0 +1,134 @@
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadConfigContextOptions(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", "{\"Name\":\"h\xffi\"}")
+
+	var c testConfig
+
+	_, err := ReadConfigContext(context.Background(), path, nil, &c, JSON[testConfig](), nil, RequireUTF8())
+	if err == nil || !strings.Contains(err.Error(), "not valid UTF-8") {
+		t.Fatalf("got error %v, want an invalid UTF-8 error", err)
+	}
+
+	err = ReadFoundConfigContext(context.Background(), path, &c, JSON[testConfig](), nil, RequireUTF8())
+	if err == nil || !strings.Contains(err.Error(), "not valid UTF-8") {
+		t.Fatalf("got error %v, want an invalid UTF-8 error", err)
+	}
+}
+
+func TestReadConfigContextDone(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":"a"}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := testConfig{Name: "initial"}
+
+	_, err := ReadConfigContext(ctx, path, nil, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+
+	if c.Name != "initial" {
+		t.Errorf("got name %q, want initial", c.Name)
+	}
+}
+
+func TestReadConfigReaderContextTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	t.Cleanup(func() { w.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := testConfig{Name: "initial"}
+
+	err := ReadConfigReaderContext(ctx, r, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	if c.Name != "initial" {
+		t.Errorf("got name %q, want initial", c.Name)
+	}
+}
+
+func TestReadConfigReaderContext(t *testing.T) {
+	var c testConfig
+
+	err := ReadConfigReaderContext(context.Background(), strings.NewReader(`{"Name":"a"}`), &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "a" {
+		t.Errorf("got name %q, want a", c.Name)
+	}
+}
+
+func TestReadConfigTimeout(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":"a"}`)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	slow := func(context.Context) FinalizeFunc[*testConfig] {
+		return func(*testConfig) error {
+			<-release
+			return nil
+		}
+	}
+
+	c := testConfig{Name: "initial"}
+
+	_, err := ReadConfigTimeout(20*time.Millisecond, path, nil, &c, JSON[testConfig](), slow)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	if c.Name != "initial" {
+		t.Errorf("got name %q, want initial", c.Name)
+	}
+}
+
+func TestReadConfigTimeoutInTime(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":"a"}`)
+
+	var deadline bool
+
+	finalize := func(ctx context.Context) FinalizeFunc[*testConfig] {
+		return func(*testConfig) error {
+			_, deadline = ctx.Deadline()
+			return nil
+		}
+	}
+
+	var c testConfig
+
+	got, err := ReadConfigTimeout(5*time.Second, path, nil, &c, JSON[testConfig](), finalize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != path || c.Name != "a" || !deadline {
+		t.Errorf("got path %q, name %q, and deadline %t, want %q, a, and true", got, c.Name, deadline, path)
+	}
+
+	errInvalid := errors.New("invalid")
+
+	_, err = ReadConfigTimeout(5*time.Second, path, nil, &c, JSON[testConfig](), func(context.Context) FinalizeFunc[*testConfig] {
+		return func(*testConfig) error { return errInvalid }
+	})
+	if !errors.Is(err, errInvalid) || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want a validation failure rather than a timeout", err)
+	}
+}
@@ -0,0 +1,94 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// decoders maps lower-case file extensions, including the leading dot, to
+// functions decoding content in the corresponding format. It is guarded by
+// decodersMu.
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]func([]byte, any) error{
+		".json": json.Unmarshal,
+		".jsonc": func(content []byte, v any) error {
+			return json.Unmarshal(stripJSONC(content), v)
+		},
+	}
+)
+
+// RegisterDecoder registers fn as the decoder for configuration files with
+// the extension ext, e.g. ".ini", for use by UnmarshalByExtension and
+// ReadConfigAuto. The extension is matched case-insensitively and the leading
+// dot may be omitted. Registering an extension again replaces the previous
+// decoder, including the built-in ones.
+//
+// The registry is global to the process. RegisterDecoder is safe for
+// concurrent use, but is typically called from an init function.
+func RegisterDecoder(ext string, fn func([]byte, any) error) {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	decoders[ext] = fn
+}
+
+// lookupDecoder returns the decoder registered for ext.
+func lookupDecoder(ext string) (func([]byte, any) error, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	fn, ok := decoders[ext]
+
+	return fn, ok
+}
+
+// UnmarshalByExtension returns an UnmarshalFunc for the format indicated by
+// the extension of path. The extension is matched case-insensitively.
+//
+// JSON (.json) and JSON with comments (.jsonc) are always supported, while
+// TOML (.toml) and YAML (.yaml, .yml) require the gonfig_toml and gonfig_yaml
+// build tags respectively. Further formats can be added using
+// RegisterDecoder. A trailing .gz extension of gzip-compressed files is
+// ignored. Returns an error if the extension is not supported.
+func UnmarshalByExtension[T any](path string) (UnmarshalFunc[*T], error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".gz" {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))))
+	}
+
+	decode, ok := lookupDecoder(ext)
+	if !ok {
+		return nil, fmt.Errorf("unsupported configuration file extension %q", ext)
+	}
+
+	return func(content []byte, c *T) error {
+		return decode(content, c)
+	}, nil
+}
+
+// ReadConfigAuto is like ReadConfig, but picks the unmarshal function based on
+// the extension of the resolved path using UnmarshalByExtension.
+func ReadConfigAuto[T any](path string, searchPaths []string, c *T, finalize FinalizeFunc[*T]) (string, error) {
+	var err error
+
+	path, err = FindConfig(path, searchPaths)
+	if err != nil {
+		return "", err
+	}
+
+	unmarshal, err := UnmarshalByExtension[T](path)
+	if err != nil {
+		return "", err
+	}
+
+	return path, ReadFoundConfig(path, c, unmarshal, finalize)
+}
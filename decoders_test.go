@@ -0,0 +1,84 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigAuto(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"config.json", "config.JSON"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, dir, name, `{"Name": "json"}`)
+
+			var c testConfig
+
+			_, err := ReadConfigAuto(path, nil, &c, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Name != "json" {
+				t.Errorf("got name %q, want json", c.Name)
+			}
+		})
+	}
+}
+
+func TestReadConfigAutoUnknownExtension(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.ini", "name = ini\n")
+
+	var c testConfig
+
+	_, err := ReadConfigAuto(path, nil, &c, nil)
+	if err == nil {
+		t.Error("got no error for an unknown extension")
+	}
+
+	_, err = UnmarshalByExtension[testConfig](filepath.Join("dir", "config"))
+	if err == nil {
+		t.Error("got no error for a missing extension")
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	t.Cleanup(func() {
+		decodersMu.Lock()
+		delete(decoders, ".ini")
+		decodersMu.Unlock()
+	})
+
+	// The leading dot may be omitted and the case is ignored.
+	RegisterDecoder("INI", func(content []byte, v any) error {
+		c, ok := v.(*testConfig)
+		if !ok {
+			return fmt.Errorf("unexpected type %T", v)
+		}
+
+		name, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "name = ")
+		if !ok {
+			return errors.New("missing name")
+		}
+
+		c.Name = name
+
+		return nil
+	})
+
+	path := writeFile(t, t.TempDir(), "config.ini", "name = ini\n")
+
+	var c testConfig
+
+	_, err := ReadConfigAuto(path, nil, &c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "ini" {
+		t.Errorf("got name %q, want ini", c.Name)
+	}
+}
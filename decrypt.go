@@ -0,0 +1,22 @@
+package gonfig
+
+import "bytes"
+
+var (
+	// ageHeader and ageArmorHeader are the headers of binary and armored
+	// age-encrypted files.
+	ageHeader      = []byte("age-encryption.org/v1\n")
+	ageArmorHeader = []byte("-----BEGIN AGE ENCRYPTED FILE-----")
+	// sopsMarker prefixes the values encrypted by sops in every format
+	// supported by sops.
+	sopsMarker = []byte("ENC[AES256_GCM,")
+)
+
+// IsEncrypted reports whether content looks like an encrypted configuration
+// file, i.e. whether it is an age-encrypted file or contains values encrypted
+// by sops. It is the default detection used by WithDecrypt.
+func IsEncrypted(content []byte) bool {
+	trimmed := bytes.TrimLeft(content, " \t\r\n")
+
+	return bytes.HasPrefix(trimmed, ageHeader) || bytes.HasPrefix(trimmed, ageArmorHeader) || bytes.Contains(content, sopsMarker)
+}
@@ -0,0 +1,103 @@
+package gonfig
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// xorMagic marks content encrypted by xorEncrypt.
+var xorMagic = []byte("XOR\n")
+
+// xorEncrypt is a fake cipher XORing every byte of content with a fixed key.
+func xorEncrypt(content []byte) []byte {
+	out := append([]byte{}, xorMagic...)
+	for _, b := range content {
+		out = append(out, b^0x5a)
+	}
+
+	return out
+}
+
+func xorDecrypt(content []byte) ([]byte, error) {
+	content, ok := bytes.CutPrefix(content, xorMagic)
+	if !ok {
+		return nil, errors.New("missing header")
+	}
+
+	out := make([]byte, len(content))
+	for i, b := range content {
+		out[i] = b ^ 0x5a
+	}
+
+	return out, nil
+}
+
+func TestWithDecrypt(t *testing.T) {
+	detect := func(content []byte) bool { return bytes.HasPrefix(content, xorMagic) }
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"encrypted", xorEncrypt([]byte(`{"Name":"secret"}`)), "secret"},
+		{"plain", []byte(`{"Name":"plain"}`), "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "config.json", string(tt.content))
+
+			var c testConfig
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, WithDecrypt(xorDecrypt, detect))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Name != tt.want {
+				t.Errorf("got name %q, want %q", c.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDecryptFailure(t *testing.T) {
+	errKey := errors.New("no identity")
+
+	c := testConfig{Name: "initial"}
+
+	err := ReadConfigBytes([]byte("age-encryption.org/v1\n-> X25519 ...\n"), &c, JSON[testConfig](), nil, WithDecrypt(func([]byte) ([]byte, error) {
+		return nil, errKey
+	}, nil))
+	if !errors.Is(err, errKey) {
+		t.Fatalf("got error %v, want %v", err, errKey)
+	}
+
+	if c.Name != "initial" {
+		t.Errorf("got name %q, want initial", c.Name)
+	}
+}
+
+func TestIsEncrypted(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"age", "age-encryption.org/v1\n-> X25519 abc\n", true},
+		{"armored age", "\n-----BEGIN AGE ENCRYPTED FILE-----\nYWdl\n", true},
+		{"sops", `{"password":"ENC[AES256_GCM,data:abc,type:str]","sops":{}}`, true},
+		{"plain", `{"password":"hunter2"}`, false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEncrypted([]byte(tt.content)); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package gonfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults populates zero-valued fields of the configuration object with
+// the value of their default struct tag, e.g. `default:"8080"`. Fields that
+// already hold a non-zero value, for instance because they were set by the
+// configuration file, are left untouched.
+//
+// Defaults can be given for strings, booleans, integers, floats,
+// time.Duration, types implementing encoding.TextUnmarshaler, and pointers to
+// any of these. Slices of these are given as comma-separated lists, e.g.
+// `default:"a,b"`.
+func ApplyDefaults[T any](c *T) error {
+	return walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+		sf := path[len(path)-1]
+
+		def, ok := sf.Tag.Lookup("default")
+		if !ok || !isLeaf(v.Type()) || !v.IsZero() {
+			return nil
+		}
+
+		err := setFromString(v, def)
+		if err != nil {
+			return fmt.Errorf("unable to apply default value for %s: %w", fieldPath(path), err)
+		}
+
+		return nil
+	})
+}
+
+// WithDefaults returns a FinalizeFunc that applies the default struct tags
+// using ApplyDefaults before calling finalize.
+func WithDefaults[T any](finalize FinalizeFunc[*T]) FinalizeFunc[*T] {
+	return func(c *T) error {
+		err := ApplyDefaults(c)
+		if err != nil {
+			return err
+		}
+
+		return finalizeConfig(c, finalize)
+	}
+}
@@ -0,0 +1,74 @@
+package gonfig
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+type defaultsConfig struct {
+	Name    string        `default:"app"`
+	Port    int           `default:"8080"`
+	Timeout time.Duration `default:"5s"`
+	Hosts   []string      `default:"a,b"`
+	Server  struct {
+		Host string `default:"localhost"`
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	var c defaultsConfig
+
+	err := ApplyDefaults(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" || c.Port != 8080 || c.Timeout != 5*time.Second || c.Server.Host != "localhost" {
+		t.Errorf("got %+v", c)
+	}
+
+	if !slices.Equal(c.Hosts, []string{"a", "b"}) {
+		t.Errorf("got hosts %q, want [a b]", c.Hosts)
+	}
+}
+
+func TestApplyDefaultsFileWins(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "file", "Hosts": ["c"]}`)
+
+	var (
+		c         defaultsConfig
+		validated defaultsConfig
+	)
+
+	_, err := ReadConfig(path, nil, &c, JSON[defaultsConfig](), WithDefaults(func(c *defaultsConfig) error {
+		validated = *c
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "file" || !slices.Equal(c.Hosts, []string{"c"}) {
+		t.Errorf("got %+v, want the file values to win", c)
+	}
+
+	if c.Port != 8080 {
+		t.Errorf("got port %d, want the default for the zero value", c.Port)
+	}
+
+	if validated.Port != 8080 {
+		t.Errorf("validation saw port %d, want defaults applied first", validated.Port)
+	}
+}
+
+func TestApplyDefaultsInvalid(t *testing.T) {
+	var c struct {
+		Port int `default:"eighty"`
+	}
+
+	err := ApplyDefaults(&c)
+	if err == nil {
+		t.Error("got no error for an invalid default")
+	}
+}
@@ -0,0 +1,102 @@
+package gonfig
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// CheckDeprecated reports the deprecated keys present in the raw content of a
+// configuration file. Fields are marked as deprecated using the deprecated
+// struct tag holding a message for users, e.g.
+// `deprecated:"use listen_addr instead"`.
+//
+// The content is decoded into a generic map using decode, e.g. json.Unmarshal,
+// so that only keys that are actually present are reported, regardless of the
+// format. onWarn is called with the dotted key and the message of every
+// deprecated key present. Keys are matched case-insensitively against the
+// json, toml, and yaml struct tags and the field name.
+func CheckDeprecated[T any](raw []byte, decode func([]byte, any) error, onWarn func(key, message string)) error {
+	var m map[string]any
+
+	err := decode(raw, &m)
+	if err != nil {
+		return fmt.Errorf("unable to decode configuration: %w", err)
+	}
+
+	checkDeprecated(reflect.TypeFor[T](), m, "", onWarn)
+
+	return nil
+}
+
+func checkDeprecated(t reflect.Type, m map[string]any, prefix string, onWarn func(key, message string)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if !isStruct(t) {
+		return
+	}
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		key, value, ok := lookupKey(m, sf)
+		if !ok {
+			continue
+		}
+
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		message, deprecated := sf.Tag.Lookup("deprecated")
+		if deprecated {
+			onWarn(key, message)
+		}
+
+		nested, ok := value.(map[string]any)
+		if ok {
+			checkDeprecated(sf.Type, nested, key, onWarn)
+		}
+	}
+}
+
+// lookupKey returns the key and value of the entry of m corresponding to the
+// field sf. If several keys correspond to it, e.g. differing in case, the
+// first one in lexical order is returned, so that the result is
+// deterministic.
+func lookupKey(m map[string]any, sf reflect.StructField) (string, any, bool) {
+	names := fieldNames(sf)
+
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		for _, name := range names {
+			if strings.EqualFold(key, name) {
+				return key, m[key], true
+			}
+		}
+	}
+
+	return "", nil, false
+}
+
+// fieldNames returns the names under which the field sf may appear in a
+// configuration file, i.e. the names given by its json, toml, and yaml struct
+// tags and the field name itself.
+func fieldNames(sf reflect.StructField) []string {
+	names := []string{sf.Name}
+
+	for _, format := range []string{"json", "toml", "yaml"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(format), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
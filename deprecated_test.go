@@ -0,0 +1,72 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+func TestCheckDeprecatedDeterministic(t *testing.T) {
+	type config struct {
+		Addr string `json:"addr" deprecated:"use listen instead"`
+	}
+
+	raw := []byte(`{"addr":"a","ADDR":"b","Addr":"c"}`)
+
+	for range 20 {
+		var keys []string
+
+		err := CheckDeprecated[config](raw, json.Unmarshal, func(key, message string) {
+			keys = append(keys, key)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(keys) != 1 || keys[0] != "ADDR" {
+			t.Fatalf("got keys %v, want [ADDR]", keys)
+		}
+	}
+}
+
+func TestCheckDeprecated(t *testing.T) {
+	type server struct {
+		Addr   string `json:"addr" deprecated:"use server.listen instead"`
+		Listen string `json:"listen"`
+	}
+
+	type config struct {
+		Host   string `json:"host" deprecated:"use server.listen instead"`
+		Debug  bool   `json:"debug" deprecated:"use log_level instead"`
+		Server server `json:"server"`
+	}
+
+	raw := []byte(`{"host": "localhost", "server": {"addr": ":80", "listen": ":8080"}}`)
+
+	got := map[string]string{}
+
+	err := CheckDeprecated[config](raw, json.Unmarshal, func(key, message string) {
+		got[key] = message
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"host":        "use server.listen instead",
+		"server.addr": "use server.listen instead",
+	}
+
+	if !maps.Equal(got, want) {
+		t.Errorf("got warnings %v, want %v", got, want)
+	}
+}
+
+func TestCheckDeprecatedDecodeError(t *testing.T) {
+	err := CheckDeprecated[testConfig]([]byte(`{`), json.Unmarshal, func(key, message string) {
+		t.Errorf("got warning for %s", key)
+	})
+	if err == nil {
+		t.Error("got no error for malformed content")
+	}
+}
@@ -0,0 +1,97 @@
+package gonfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldInfo describes a configuration key supported by a configuration
+// struct, as returned by DescribeConfig.
+type FieldInfo struct {
+	// Key is the dotted key of the field, e.g. database.url, made up of the
+	// names given by the json, toml, or yaml struct tags of the field and
+	// its enclosing struct fields, falling back to the field names.
+	Key string
+	// Type is the type of the field.
+	Type reflect.Type
+	// Default is the value of the default struct tag, or empty if there is
+	// none.
+	Default string
+	// Required reports whether the field is tagged with `gonfig:"required"`.
+	Required bool
+	// Comment is the value of the comment struct tag, or empty if there is
+	// none.
+	Comment string
+}
+
+// DescribeConfig returns a description of every configuration key supported
+// by the configuration struct T in field order, e.g. to generate
+// documentation or a --help-config output. Nested structs and pointers to
+// structs are described field by field rather than as a single key.
+func DescribeConfig[T any]() []FieldInfo {
+	return describeType(reflect.TypeFor[T](), "", nil)
+}
+
+// describeType appends the description of the fields of t to infos, prefixing
+// their keys with prefix. Types already being described further up are
+// skipped to support recursive types.
+func describeType(t reflect.Type, prefix string, seen []reflect.Type) []FieldInfo {
+	var infos []FieldInfo
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for _, s := range seen {
+		if s == t {
+			return nil
+		}
+	}
+
+	seen = append(seen, t)
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		key := fieldKey(sf)
+		if key == "-" {
+			continue
+		}
+
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if !isLeaf(sf.Type) {
+			infos = append(infos, describeType(sf.Type, key, seen)...)
+			continue
+		}
+
+		infos = append(infos, FieldInfo{
+			Key:      key,
+			Type:     sf.Type,
+			Default:  sf.Tag.Get("default"),
+			Required: parseTag(sf).has("required"),
+			Comment:  sf.Tag.Get("comment"),
+		})
+	}
+
+	return infos
+}
+
+// fieldKey returns the key of the field sf in a configuration file, i.e. the
+// name given by its json, toml, or yaml struct tag, in that order, or the
+// field name. Returns "-" if the field is excluded.
+func fieldKey(sf reflect.StructField) string {
+	for _, format := range []string{"json", "toml", "yaml"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(format), ",")
+		if name != "" {
+			return name
+		}
+	}
+
+	return sf.Name
+}
@@ -0,0 +1,50 @@
+package gonfig
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDescribeConfig(t *testing.T) {
+	type database struct {
+		URL     string        `json:"url" gonfig:"required"`
+		Timeout time.Duration `json:"timeout" default:"5s"`
+	}
+
+	type config struct {
+		Name     string    `json:"name" default:"app" comment:"Name of the application."`
+		Database database  `json:"database"`
+		Replica  *database `toml:"replica"`
+		Ignored  string    `json:"-"`
+		Tags     []string
+	}
+
+	got := DescribeConfig[config]()
+
+	want := []FieldInfo{
+		{Key: "name", Type: reflect.TypeFor[string](), Default: "app", Comment: "Name of the application."},
+		{Key: "database.url", Type: reflect.TypeFor[string](), Required: true},
+		{Key: "database.timeout", Type: reflect.TypeFor[time.Duration](), Default: "5s"},
+		{Key: "replica.url", Type: reflect.TypeFor[string](), Required: true},
+		{Key: "replica.timeout", Type: reflect.TypeFor[time.Duration](), Default: "5s"},
+		{Key: "Tags", Type: reflect.TypeFor[[]string]()},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDescribeConfigRecursive(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	got := DescribeConfig[node]()
+	if len(got) != 1 || got[0].Key != "Name" {
+		t.Errorf("got %+v, want only the Name key", got)
+	}
+}
@@ -0,0 +1,72 @@
+package gonfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Result holds the outcome of reading a configuration file.
+type Result[T any] struct {
+	// Path is the resolved path of the configuration file.
+	Path string
+	// Source reports whether Path is the primary path or a fallback path.
+	Source Source
+	// Raw is the unprocessed content of the configuration file.
+	Raw []byte
+	// Hash is the fingerprint of Raw as returned by Fingerprint.
+	Hash string
+	// Value is the unmarshaled and validated configuration object.
+	Value T
+	// BytesRead is the number of bytes read from the configuration file.
+	BytesRead int
+	// Duration is the time spent reading the configuration file, excluding
+	// unmarshaling and validation.
+	Duration time.Duration
+}
+
+// ReadConfigDetailed is like ReadConfig, but returns the resolved path and the
+// raw content of the configuration file along with the configuration object,
+// as well as the size of the content and the time it took to read it, e.g. to
+// record them as metrics.
+func ReadConfigDetailed[T any](path string, searchPaths []string, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) (Result[T], error) {
+	var (
+		res Result[T]
+		err error
+	)
+
+	found, err := findConfig(osFinder, path, searchPaths, nil)
+	if err != nil {
+		return res, err
+	}
+
+	res.Path, res.Source = found.Path, found.Source
+
+	start := time.Now()
+
+	res.Raw, err = readFile(res.Path, nil)
+	res.Duration = time.Since(start)
+	res.BytesRead = len(res.Raw)
+	if err != nil {
+		return res, fmt.Errorf("unable to read configuration file %s: %w", res.Path, err)
+	}
+
+	res.Hash = Fingerprint(res.Raw)
+
+	err = processConfig(res.Path, res.Raw, &res.Value, unmarshal, finalize, nil)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Fingerprint returns the hex-encoded SHA-256 hash of the raw content of a
+// configuration file. Since it is computed over the raw bytes, it is stable
+// across runs and changes whenever the content changes.
+func Fingerprint(raw []byte) string {
+	sum := sha256.Sum256(raw)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,118 @@
+package gonfig
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadConfigDetailed(t *testing.T) {
+	dir := t.TempDir()
+	content := "{\n  \"Name\": \"app\"\n}\n"
+	path := writeFile(t, dir, "config.json", content)
+
+	res, err := ReadConfigDetailed("", []string{filepath.Join(dir, "missing.json"), path}, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Path != path {
+		t.Errorf("got path %q, want %q", res.Path, path)
+	}
+
+	if !bytes.Equal(res.Raw, []byte(content)) {
+		t.Errorf("got raw %q, want %q", res.Raw, content)
+	}
+
+	if res.Value.Name != "app" {
+		t.Errorf("got name %q, want app", res.Value.Name)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint([]byte(`{"Name": "a"}`))
+
+	if got := Fingerprint([]byte(`{"Name": "a"}`)); got != a {
+		t.Errorf("got fingerprint %s for the same content, want %s", got, a)
+	}
+
+	if got := Fingerprint([]byte(`{"Name": "b"}`)); got == a {
+		t.Error("got the same fingerprint for different content")
+	}
+
+	// The SHA-256 hash of the empty string.
+	if got, want := Fingerprint(nil), "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"; got != want {
+		t.Errorf("got fingerprint %s, want %s", got, want)
+	}
+
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "a"}`)
+
+	res, err := ReadConfigDetailed(path, nil, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Hash != a {
+		t.Errorf("got hash %s, want %s", res.Hash, a)
+	}
+}
+
+func TestReadConfigDetailedMetrics(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "app", "Port": 8080}`)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := ReadConfigDetailed(path, nil, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if int64(res.BytesRead) != info.Size() {
+		t.Errorf("got %d bytes read, want %d", res.BytesRead, info.Size())
+	}
+
+	if res.Duration < 0 {
+		t.Errorf("got negative duration %s", res.Duration)
+	}
+}
+
+func TestReadConfigDetailedSource(t *testing.T) {
+	dir := t.TempDir()
+	primary := writeFile(t, dir, "primary.json", `{"Name": "primary"}`)
+	fallback := writeFile(t, dir, "fallback.json", `{"Name": "fallback"}`)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantPath   string
+		wantSource Source
+	}{
+		{"primary used", primary, primary, Primary},
+		{"fallback used", "", fallback, Fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ReadConfigDetailed(tt.path, []string{filepath.Join(dir, "missing.json"), fallback}, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res.Path != tt.wantPath || res.Source != tt.wantSource {
+				t.Errorf("got %q from %v, want %q from %v", res.Path, res.Source, tt.wantPath, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestSourceString(t *testing.T) {
+	for source, want := range map[Source]string{Default: "default", Primary: "primary", Fallback: "fallback", 7: "Source(7)"} {
+		if got := source.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
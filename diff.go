@@ -0,0 +1,65 @@
+package gonfig
+
+import (
+	"reflect"
+)
+
+// FieldChange describes a field whose value differs between two
+// configuration objects, as returned by Diff.
+type FieldChange struct {
+	// Path is the dotted path of the field, e.g. Database.URL.
+	Path string
+	// Old and New are the values of the field in the current and the
+	// proposed configuration object.
+	Old, New any
+}
+
+// Diff compares the current and the proposed configuration object field by
+// field and returns the changes in field order, e.g. to preview the effect
+// of applying a new configuration. Nested structs and pointers to structs are
+// compared field by field, while all other values, including slices and maps,
+// are reported as a whole if they are not deeply equal. Returns nil if the
+// objects do not differ.
+//
+// If T is not a struct, e.g. a map[string]any, the objects are compared as a
+// whole, and at most one change with an empty path is reported.
+func Diff[T any](current, proposed T) []FieldChange {
+	a, b := reflect.ValueOf(&current).Elem(), reflect.ValueOf(&proposed).Elem()
+
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(current, proposed) {
+			return nil
+		}
+
+		return []FieldChange{{Old: current, New: proposed}}
+	}
+
+	return diffValue(nil, a, b, nil)
+}
+
+// diffValue appends the changes between the struct values a and b, whose
+// fields are located at path, to changes.
+func diffValue(changes []FieldChange, a, b reflect.Value, path []reflect.StructField) []FieldChange {
+	t := a.Type()
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		p := append(path[:len(path):len(path)], sf)
+		fa, fb := a.Field(i), b.Field(i)
+
+		switch {
+		case isStruct(sf.Type):
+			changes = diffValue(changes, fa, fb, p)
+		case sf.Type.Kind() == reflect.Pointer && isStruct(sf.Type.Elem()) && !fa.IsNil() && !fb.IsNil():
+			changes = diffValue(changes, fa.Elem(), fb.Elem(), p)
+		case !reflect.DeepEqual(fa.Interface(), fb.Interface()):
+			changes = append(changes, FieldChange{Path: fieldPath(p), Old: fa.Interface(), New: fb.Interface()})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,75 @@
+package gonfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+type diffDatabase struct {
+	URL  string
+	Port int
+}
+
+type diffConfig struct {
+	Name     string
+	Database diffDatabase
+	Cache    *diffDatabase
+	Hosts    []string
+	Labels   map[string]string
+	internal int
+}
+
+func TestDiff(t *testing.T) {
+	current := diffConfig{
+		Name:     "app",
+		Database: diffDatabase{URL: "postgres://a", Port: 5432},
+		Cache:    &diffDatabase{URL: "redis://a"},
+		Hosts:    []string{"a", "b"},
+		Labels:   map[string]string{"env": "dev"},
+		internal: 1,
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(c *diffConfig)
+		want   []FieldChange
+	}{
+		{"no changes", func(c *diffConfig) {
+			c.Cache = &diffDatabase{URL: "redis://a"}
+			c.Hosts = []string{"a", "b"}
+			c.internal = 2
+		}, nil},
+		{"scalar", func(c *diffConfig) { c.Name = "other" }, []FieldChange{
+			{Path: "Name", Old: "app", New: "other"},
+		}},
+		{"nested", func(c *diffConfig) {
+			c.Database.Port = 5433
+			c.Cache = &diffDatabase{URL: "redis://b"}
+		}, []FieldChange{
+			{Path: "Database.Port", Old: 5432, New: 5433},
+			{Path: "Cache.URL", Old: "redis://a", New: "redis://b"},
+		}},
+		{"slices and maps", func(c *diffConfig) {
+			c.Hosts = []string{"a"}
+			c.Labels = map[string]string{"env": "prod"}
+		}, []FieldChange{
+			{Path: "Hosts", Old: []string{"a", "b"}, New: []string{"a"}},
+			{Path: "Labels", Old: map[string]string{"env": "dev"}, New: map[string]string{"env": "prod"}},
+		}},
+		{"nil pointer", func(c *diffConfig) { c.Cache = nil }, []FieldChange{
+			{Path: "Cache", Old: current.Cache, New: (*diffDatabase)(nil)},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposed := current
+			tt.mutate(&proposed)
+
+			got := Diff(current, proposed)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package gonfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// ReadConfigDir reads the configuration fragments in the directory dir and
+// merges them into the given configuration object in lexical order of their
+// names, similar to the drop-in directories of systemd. The merged result is
+// validated once.
+//
+// Only regular files with the extension ext, e.g. ".toml", are read, unless
+// ext is empty. Symbolic links are followed. Files whose names start with a
+// dot are skipped, as are subdirectories and other files that are not
+// regular. Each fragment is unmarshaled into a zero value of type T and
+// merged into a copy of c using merge, which defaults to Merge if nil. The
+// copy only replaces *c if reading, merging, and validation succeed. An empty
+// directory leaves c untouched, so that drop-in directories can be optional.
+func ReadConfigDir[T any](dir string, ext string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], merge func(dst, src *T)) error {
+	if merge == nil {
+		merge = Merge[T]
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration directory %s: %w", dir, err)
+	}
+
+	next := *c
+	copyPointers(reflect.ValueOf(&next).Elem())
+
+	// os.ReadDir returns the entries sorted by name.
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || (ext != "" && filepath.Ext(name) != ext) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		// Follow symbolic links, which are common in drop-in directories.
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("could not stat configuration file %s: %w", path, err)
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		var layer T
+
+		err = readFoundConfig(path, &layer, unmarshal, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		merge(&next, &layer)
+	}
+
+	err = finalizeConfig(&next, finalize)
+	if err != nil {
+		return err
+	}
+
+	*c = next
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadConfigDirAllOrNothing(t *testing.T) {
+	type config struct {
+		Name string
+		Port int
+	}
+
+	tests := []struct {
+		name     string
+		second   string
+		finalize FinalizeFunc[*config]
+	}{
+		{"syntax error", `{`, nil},
+		{"validation error", `{"Port":1}`, func(*config) error { return errors.New("invalid") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(dir, "10-first.json"), []byte(`{"Name":"first"}`), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = os.WriteFile(filepath.Join(dir, "20-second.json"), []byte(tt.second), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := config{Name: "initial"}
+
+			err = ReadConfigDir(dir, ".json", &c, JSON[config](), tt.finalize, nil)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			if c != (config{Name: "initial"}) {
+				t.Errorf("got %+v, want the initial configuration", c)
+			}
+		})
+	}
+}
+
+func TestReadConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "30-last.json", `{"Name":"last"}`)
+	writeFile(t, dir, "10-first.json", `{"Name":"first","Port":1}`)
+	writeFile(t, dir, "20-second.json", `{"Name":"second","Port":2}`)
+	writeFile(t, dir, ".hidden.json", `{"Name":"hidden"}`)
+	writeFile(t, dir, "40-other.toml", `Name = "other"`)
+
+	err := os.Mkdir(filepath.Join(dir, "50-sub.json"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c testConfig
+
+	err = ReadConfigDir(dir, ".json", &c, JSON[testConfig](), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (testConfig{Name: "last", Port: 2}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+
+	var names []string
+
+	err = ReadConfigDir(dir, ".json", &c, JSON[testConfig](), nil, func(dst, src *testConfig) {
+		names = append(names, src.Name)
+		Merge(dst, src)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"first", "second", "last"}; !slices.Equal(names, want) {
+		t.Errorf("got merge order %q, want %q", names, want)
+	}
+}
+
+func TestReadConfigDirEmpty(t *testing.T) {
+	c := testConfig{Name: "initial"}
+
+	validated := false
+
+	err := ReadConfigDir(t.TempDir(), ".json", &c, JSON[testConfig](), func(*testConfig) error {
+		validated = true
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c != (testConfig{Name: "initial"}) || !validated {
+		t.Errorf("got %+v and validated %t, want the initial configuration validated", c, validated)
+	}
+
+	err = ReadConfigDir(filepath.Join(t.TempDir(), "missing"), ".json", &c, JSON[testConfig](), nil, nil)
+	if err == nil {
+		t.Error("got no error for a missing directory")
+	}
+}
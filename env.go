@@ -0,0 +1,299 @@
+package gonfig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ApplyEnv overrides fields of the configuration object with values from
+// environment variables. It is meant to be called after the configuration
+// file has been read, so that the environment takes precedence over the file.
+//
+// The variable for a field is named after the upper-cased field name, joined
+// with the names of its enclosing struct fields and the prefix using
+// underscores, e.g. APP_DATABASE_URL for the field Database.URL and the prefix
+// APP. An env struct tag replaces the derived name entirely, e.g.
+// `env:"DATABASE_URL"`; on a struct field it replaces the prefix used for its
+// nested fields instead. A tag of "-" excludes the field. Unset variables
+// leave the field untouched. A nil pointer to a struct is allocated if a
+// variable for one of its fields is set and stays nil otherwise.
+//
+// Supported field types are strings, booleans, integers, floats,
+// time.Duration, types implementing encoding.TextUnmarshaler, pointers to any
+// of these, and slices of any of these, which are given as comma-separated
+// lists, e.g. APP_HOSTS=a,b.
+func ApplyEnv[T any](c *T, prefix string) error {
+	return applyEnv(c, prefix, os.LookupEnv)
+}
+
+// applyEnv implements ApplyEnv, looking up variables using lookup.
+func applyEnv[T any](c *T, prefix string, lookup func(string) (string, bool)) error {
+	_, err := applyEnvFields(reflect.ValueOf(c).Elem(), nil, prefix, lookup)
+
+	return err
+}
+
+// applyEnvFields applies the environment variables for the fields of the
+// struct v at the given path and reports whether any of them was set. Nil
+// pointers to structs are descended into like EnvVars does, including
+// recursive types only once per path.
+func applyEnvFields(v reflect.Value, path []reflect.StructField, prefix string, lookup func(string) (string, bool)) (bool, error) {
+	var set bool
+
+	err := walkFieldsPath(v, path, func(path []reflect.StructField, v reflect.Value) error {
+		if !isLeaf(v.Type()) {
+			if v.Kind() != reflect.Pointer || !v.IsNil() {
+				return nil
+			}
+
+			ok, err := applyEnvNil(v, path, prefix, lookup)
+			if ok {
+				set = true
+			}
+
+			return err
+		}
+
+		name, ok := envName(prefix, path)
+		if !ok {
+			return nil
+		}
+
+		s, ok := lookup(name)
+		if !ok {
+			return nil
+		}
+
+		err := setFromString(v, s)
+		if err != nil {
+			return fmt.Errorf("unable to apply environment variable %s: %w", name, err)
+		}
+
+		set = true
+
+		return nil
+	})
+
+	return set, err
+}
+
+// applyEnvNil applies the environment variables for the fields of the struct
+// that the nil pointer v at the given path would point to, and only sets v to
+// a newly allocated struct if any of them was set.
+func applyEnvNil(v reflect.Value, path []reflect.StructField, prefix string, lookup func(string) (string, bool)) (bool, error) {
+	t := v.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if slices.ContainsFunc(path[:len(path)-1], func(sf reflect.StructField) bool { return sf.Type == t || sf.Type == reflect.PointerTo(t) }) {
+		return false, nil
+	}
+
+	p := reflect.New(v.Type()).Elem()
+
+	target := p
+	for target.Kind() == reflect.Pointer {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	set, err := applyEnvFields(target, path, prefix, lookup)
+	if set {
+		v.Set(p)
+	}
+
+	return set, err
+}
+
+// EnvVars returns the names of all environment variables consulted by
+// ApplyEnv with the given prefix for configuration objects of type T, in the
+// order of the fields, e.g. for a "config env" command listing them. Fields
+// within nested structs and pointers to structs are included, since ApplyEnv
+// allocates nil pointers to structs as needed, while excluded fields are not.
+func EnvVars[T any](prefix string) []string {
+	var names []string
+
+	envVars(reflect.TypeFor[T](), prefix, nil, &names)
+
+	return names
+}
+
+// envVars appends the names of the environment variables for the fields of
+// the struct type t at the given path to names. Recursive types are only
+// descended into once per path.
+func envVars(t reflect.Type, prefix string, path []reflect.StructField, names *[]string) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		p := append(path[:len(path):len(path)], sf)
+
+		if isLeaf(sf.Type) {
+			name, ok := envName(prefix, p)
+			if ok {
+				*names = append(*names, name)
+			}
+
+			continue
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if !slices.ContainsFunc(path, func(sf reflect.StructField) bool { return sf.Type == ft || sf.Type == reflect.PointerTo(ft) }) {
+			envVars(ft, prefix, p, names)
+		}
+	}
+}
+
+// EnvBacked holds a configuration object read from a configuration file and
+// resolves environment variable overrides whenever it is accessed, rather
+// than once at load time as ApplyEnv does, so that later changes to the
+// environment take effect on the next access. It is safe for concurrent use.
+type EnvBacked[T any] struct {
+	file   T
+	prefix string
+}
+
+// NewEnvBacked returns an EnvBacked for the configuration object c, whose
+// fields are overridden by the environment variables named as described for
+// ApplyEnv using prefix.
+func NewEnvBacked[T any](c T, prefix string) *EnvBacked[T] {
+	return &EnvBacked[T]{file: c, prefix: prefix}
+}
+
+// Get returns a copy of the configuration object in which each field whose
+// environment variable is currently set holds the value of the variable,
+// while all other fields hold the value from the configuration file. The
+// configuration object passed to NewEnvBacked is left untouched. Returns an
+// error if a variable cannot be parsed for its field.
+func (e *EnvBacked[T]) Get() (T, error) {
+	c := e.file
+
+	copyPointers(reflect.ValueOf(&c).Elem())
+
+	err := ApplyEnv(&c, e.prefix)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return c, nil
+}
+
+// File returns the configuration object as read from the configuration file,
+// without environment variable overrides.
+func (e *EnvBacked[T]) File() T {
+	return e.file
+}
+
+// copyPointers replaces the non-nil pointer fields of the struct v and of
+// its nested structs with pointers to copies, so that setting fields of v
+// does not affect the struct it was copied from.
+func copyPointers(v reflect.Value) {
+	walkFields(v, func(path []reflect.StructField, v reflect.Value) error {
+		if v.Kind() == reflect.Pointer && !v.IsNil() {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(v.Elem())
+			v.Set(p)
+		}
+
+		return nil
+	})
+}
+
+// EnvOnly returns a FinalizeFunc that reports an error for every field tagged
+// with `gonfig:"envonly"` that is set by the configuration file, enforcing
+// that secrets are only passed using environment variables and never end up
+// in a configuration file. Such fields are then set using ApplyEnv.
+//
+// The check treats any non-zero value as set by the file, so it must run
+// before environment variables and default values are applied, e.g. as the
+// first validator passed to ReadConfig. The errors of all offending fields
+// are joined.
+func EnvOnly[T any]() FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			if parseTag(path[len(path)-1]).has("envonly") && !v.IsZero() {
+				errs = append(errs, fmt.Errorf("field %s must only be set using the environment, not the configuration file", fieldPath(path)))
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// ReadConfigFromEnv reads a configuration from the environment variable
+// envVar, which holds the content of a whole configuration file, e.g. as
+// passed by Kubernetes, unmarshals it into the given configuration object, and
+// validates it.
+//
+// If decode is not nil, it unwraps the content before unmarshaling, e.g.
+// DecodeBase64 for base64-encoded content. Otherwise the content is used as
+// is. Returns an error if the variable is not set or if the content cannot be
+// decoded, unmarshaled, or validated.
+func ReadConfigFromEnv[T any](envVar string, decode func([]byte) ([]byte, error), c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	s, ok := os.LookupEnv(envVar)
+	if !ok {
+		return fmt.Errorf("environment variable %s is not set", envVar)
+	}
+
+	content := []byte(s)
+
+	if decode != nil {
+		var err error
+
+		content, err = decode(content)
+		if err != nil {
+			return fmt.Errorf("unable to decode environment variable %s: %w", envVar, err)
+		}
+	}
+
+	return processConfig("", content, c, unmarshal, finalize, nil)
+}
+
+// DecodeBase64 decodes standard base64-encoded content, ignoring surrounding
+// white space. It is meant to be passed to ReadConfigFromEnv.
+func DecodeBase64(content []byte) ([]byte, error) {
+	return base64.StdEncoding.AppendDecode(nil, bytes.TrimSpace(content))
+}
+
+// envName returns the name of the environment variable for the field at the
+// given path, or false if the field is excluded.
+func envName(prefix string, path []reflect.StructField) (string, bool) {
+	name := prefix
+
+	for _, sf := range path {
+		tag := sf.Tag.Get("env")
+
+		switch tag {
+		case "-":
+			return "", false
+		case "":
+			if name == "" {
+				name = strings.ToUpper(sf.Name)
+			} else {
+				name += "_" + strings.ToUpper(sf.Name)
+			}
+		default:
+			name = tag
+		}
+	}
+
+	return name, true
+}
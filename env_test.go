@@ -0,0 +1,296 @@
+package gonfig
+
+import (
+	"encoding/base64"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type envConfig struct {
+	Name     string
+	Debug    bool
+	Timeout  time.Duration
+	Database struct {
+		URL  string `env:"DATABASE_URL"`
+		Port int
+	}
+	Secret string `env:"-"`
+}
+
+func TestApplyEnv(t *testing.T) {
+	t.Setenv("APP_NAME", "env")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_TIMEOUT", "3s")
+	t.Setenv("DATABASE_URL", "postgres://db")
+	t.Setenv("APP_DATABASE_PORT", "5432")
+	t.Setenv("APP_SECRET", "leaked")
+
+	var c envConfig
+
+	err := ApplyEnv(&c, "APP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "env" || !c.Debug || c.Timeout != 3*time.Second {
+		t.Errorf("got %+v", c)
+	}
+
+	if c.Database.URL != "postgres://db" || c.Database.Port != 5432 {
+		t.Errorf("got database %+v", c.Database)
+	}
+
+	if c.Secret != "" {
+		t.Errorf("got secret %q from an excluded field", c.Secret)
+	}
+}
+
+func TestApplyEnvBeatsFile(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "file", "Port": 8080}`)
+
+	t.Setenv("APP_NAME", "env")
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ApplyEnv(&c, "APP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "env" {
+		t.Errorf("got name %q, want the environment to win", c.Name)
+	}
+
+	if c.Port != 8080 {
+		t.Errorf("got port %d, want the file value to be kept", c.Port)
+	}
+}
+
+func TestApplyEnvInvalid(t *testing.T) {
+	t.Setenv("APP_PORT", "eighty")
+
+	var c testConfig
+
+	err := ApplyEnv(&c, "APP")
+	if err == nil {
+		t.Error("got no error for an invalid integer")
+	}
+}
+
+func TestReadConfigFromEnv(t *testing.T) {
+	content := `{"Name": "app", "Port": 8080}`
+
+	tests := []struct {
+		name   string
+		value  string
+		decode func([]byte) ([]byte, error)
+	}{
+		{"plain", content, nil},
+		{"base64", base64.StdEncoding.EncodeToString([]byte(content)) + "\n", DecodeBase64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APP_CONFIG", tt.value)
+
+			var c testConfig
+
+			err := ReadConfigFromEnv("APP_CONFIG", tt.decode, &c, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := (testConfig{Name: "app", Port: 8080}); c != want {
+				t.Errorf("got %+v, want %+v", c, want)
+			}
+		})
+	}
+}
+
+func TestReadConfigFromEnvErrors(t *testing.T) {
+	t.Setenv("APP_CONFIG", "not base64!")
+
+	c := testConfig{Name: "initial"}
+
+	err := ReadConfigFromEnv("APP_CONFIG", DecodeBase64, &c, JSON[testConfig](), nil)
+	if err == nil || !strings.Contains(err.Error(), "APP_CONFIG") {
+		t.Errorf("got error %v, want a decoding error naming APP_CONFIG", err)
+	}
+
+	if c != (testConfig{Name: "initial"}) {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+
+	err = ReadConfigFromEnv("GONFIG_TEST_UNSET", nil, &c, JSON[testConfig](), nil)
+	if err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("got error %v, want an error for an unset variable", err)
+	}
+}
+
+func TestEnvOnly(t *testing.T) {
+	type config struct {
+		Name     string
+		Password string `gonfig:"envonly"`
+	}
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"set by file", `{"Name": "app", "Password": "hunter2"}`, true},
+		{"left for environment", `{"Name": "app"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APP_PASSWORD", "from-env")
+
+			path := writeFile(t, dir, "config.json", tt.content)
+
+			var c config
+
+			_, err := ReadConfig(path, nil, &c, JSON[config](), EnvOnly[config]())
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "field Password must only be set using the environment") {
+					t.Errorf("got error %v, want an envonly error", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ApplyEnv(&c, "APP")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Password != "from-env" {
+				t.Errorf("got password %q, want it from the environment", c.Password)
+			}
+		})
+	}
+}
+
+func TestEnvBacked(t *testing.T) {
+	type config struct {
+		Name    string
+		Timeout *time.Duration
+	}
+
+	timeout := time.Second
+
+	t.Setenv("APP_NAME", "")
+	os.Unsetenv("APP_NAME")
+
+	e := NewEnvBacked(config{Name: "file", Timeout: &timeout}, "APP")
+
+	c, err := e.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "file" || *c.Timeout != time.Second {
+		t.Errorf("got %+v, want the values from the file", c)
+	}
+
+	t.Setenv("APP_NAME", "env")
+	t.Setenv("APP_TIMEOUT", "5s")
+
+	c, err = e.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "env" || *c.Timeout != 5*time.Second {
+		t.Errorf("got name %q and timeout %s, want the values from the environment", c.Name, *c.Timeout)
+	}
+
+	if f := e.File(); f.Name != "file" || timeout != time.Second {
+		t.Errorf("got file name %q and timeout %s, want the file left untouched", f.Name, timeout)
+	}
+
+	t.Setenv("APP_TIMEOUT", "soon")
+
+	_, err = e.Get()
+	if err == nil || !strings.Contains(err.Error(), "APP_TIMEOUT") {
+		t.Errorf("got error %v, want one naming APP_TIMEOUT", err)
+	}
+}
+
+func TestEnvVars(t *testing.T) {
+	got := EnvVars[envConfig]("APP")
+
+	want := []string{"APP_NAME", "APP_DEBUG", "APP_TIMEOUT", "DATABASE_URL", "APP_DATABASE_PORT"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	type config struct {
+		Cache struct {
+			Host string
+		} `env:"REDIS"`
+		TLS  *struct{ CertFile string }
+		Tree node
+	}
+
+	got = EnvVars[config]("")
+
+	want = []string{"REDIS_HOST", "TLS_CERTFILE", "TREE_NAME"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestApplyEnvNilStructs(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	type config struct {
+		TLS   *struct{ CertFile string }
+		Cache *struct{ Host string }
+		Tree  node
+	}
+
+	t.Setenv("TLS_CERTFILE", "cert.pem")
+	t.Setenv("TREE_NEXT_NAME", "next")
+
+	var c config
+
+	err := ApplyEnv(&c, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.TLS == nil || c.TLS.CertFile != "cert.pem" {
+		t.Errorf("got TLS %+v, want it allocated and set from TLS_CERTFILE", c.TLS)
+	}
+
+	if c.Cache != nil {
+		t.Errorf("got cache %+v, want it to stay nil without variables", c.Cache)
+	}
+
+	if c.Tree.Next != nil {
+		t.Errorf("got next node %+v, want it to stay nil as it is not listed by EnvVars", c.Tree.Next)
+	}
+}
@@ -0,0 +1,199 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// ErrConfigNotFound is reported when no configuration file could be located.
+// Use errors.Is to check for it.
+var ErrConfigNotFound = errors.New("could not locate configuration file")
+
+// NotFoundError is returned when no configuration file could be located.
+//
+// Paths holds the paths that were searched, in order. If the primary path was
+// given and does not exist, Paths only holds the primary path and Err holds
+// the underlying stat error.
+type NotFoundError struct {
+	Paths []string
+	Err   error
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Err != nil && len(e.Paths) == 1 {
+		return fmt.Sprintf("could not stat configuration file %s: %v", e.Paths[0], e.Err)
+	}
+
+	if len(e.Paths) == 0 {
+		return ErrConfigNotFound.Error()
+	}
+
+	return fmt.Sprintf("%s in any of: %s", ErrConfigNotFound, strings.Join(e.Paths, ", "))
+}
+
+// Is reports whether target is ErrConfigNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrConfigNotFound
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalError is returned when the content of a configuration file cannot
+// be unmarshaled. Use errors.As to access the offending content, e.g. to log
+// a snippet of it.
+//
+// The position of the error is extracted from the errors of encoding/json and
+// of the TOML and YAML adapters.
+type UnmarshalError struct {
+	// Path is the path of the configuration file, or empty if the content
+	// does not originate from a file.
+	Path string
+	// Content is the content that could not be unmarshaled.
+	Content []byte
+	// Line and Column are the 1-based position of the error within Content,
+	// if provided by the unmarshal function, or 0 if unknown.
+	Line, Column int
+	// Err is the error returned by the unmarshal function.
+	Err error
+}
+
+func (e *UnmarshalError) Error() string {
+	return fmt.Sprintf("unable to unmarshal %s: %v", describe(e.Path), e.Err)
+}
+
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// StageError is returned by ReadConfigPipeline when one of its stages fails.
+type StageError struct {
+	// Stage is the name of the failing stage, e.g. StageValidate.
+	Stage string
+	// Err is the error returned by the stage.
+	Err error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("%s stage failed: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// describe returns a description of the configuration file at path for use
+// in error messages.
+func describe(path string) string {
+	if path == "" {
+		return "configuration"
+	}
+
+	return "configuration file " + path
+}
+
+// FormatError returns a concise, user-facing message for an error returned
+// by the read functions, e.g. for the output of a command-line tool, whereas
+// the error itself is meant for logs. It recognizes the errors of this
+// package:
+//
+//   - *NotFoundError, e.g. "config file not found; tried: a, b, c"
+//   - *UnmarshalError, e.g. "invalid config in /etc/app.toml at line 4", for
+//     both syntax errors and values of the wrong type
+//   - *StageError, using the name of the failing stage
+//
+// Errors joined by errors.Join or CombineValidators, e.g. validation errors,
+// are listed one per line. Other errors are returned as is.
+func FormatError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var nf *NotFoundError
+	if errors.As(err, &nf) {
+		switch {
+		case len(nf.Paths) == 1 && errors.Is(nf.Err, fs.ErrNotExist):
+			return fmt.Sprintf("config file %s not found", nf.Paths[0])
+		case len(nf.Paths) == 1 && nf.Err != nil:
+			return fmt.Sprintf("config file %s is not accessible: %v", nf.Paths[0], innermost(nf.Err))
+		case len(nf.Paths) == 0:
+			return "config file not found"
+		default:
+			return fmt.Sprintf("config file not found; tried: %s", strings.Join(nf.Paths, ", "))
+		}
+	}
+
+	var ue *UnmarshalError
+	if errors.As(err, &ue) {
+		var b strings.Builder
+
+		b.WriteString("invalid config")
+
+		if ue.Path != "" {
+			fmt.Fprintf(&b, " in %s", ue.Path)
+		}
+
+		if ue.Line > 0 {
+			fmt.Fprintf(&b, " at line %d", ue.Line)
+
+			if ue.Column > 0 {
+				fmt.Fprintf(&b, ", column %d", ue.Column)
+			}
+		}
+
+		fmt.Fprintf(&b, ": %v", ue.Err)
+
+		return b.String()
+	}
+
+	var se *StageError
+	if errors.As(err, &se) {
+		if se.Stage == StageValidate {
+			return formatJoined("invalid config", se.Err)
+		}
+
+		return formatJoined("config "+se.Stage+" failed", se.Err)
+	}
+
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		return formatJoined("invalid config", err)
+	}
+
+	return err.Error()
+}
+
+// formatJoined formats err prefixed with msg, listing joined errors one per
+// line.
+func formatJoined(msg string, err error) string {
+	errs := splitErrors(err)
+	if len(errs) == 1 {
+		return msg + ": " + errs[0].Error()
+	}
+
+	var b strings.Builder
+
+	b.WriteString(msg + ":")
+
+	for _, err := range errs {
+		b.WriteString("\n  - " + err.Error())
+	}
+
+	return b.String()
+}
+
+// innermost returns the message of the innermost error wrapped by err, which
+// omits the context repeated by the wrapping errors, e.g. "permission denied"
+// instead of "stat /etc/app.toml: permission denied".
+func innermost(err error) string {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err.Error()
+		}
+
+		err = next
+	}
+}
@@ -0,0 +1,142 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestErrConfigNotFound(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("empty search", func(t *testing.T) {
+		_, err := FindConfig("", nil)
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want ErrConfigNotFound", err)
+		}
+	})
+
+	t.Run("missing fallbacks", func(t *testing.T) {
+		paths := []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}
+
+		_, err := FindConfig("", paths)
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Fatalf("got error %v, want ErrConfigNotFound", err)
+		}
+
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) || !slices.Equal(notFound.Paths, paths) {
+			t.Errorf("got error %#v, want a *NotFoundError listing %q", err, paths)
+		}
+	})
+
+	t.Run("missing primary", func(t *testing.T) {
+		path := filepath.Join(dir, "missing.json")
+
+		var c testConfig
+
+		_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want ErrConfigNotFound", err)
+		}
+
+		_, err = FindConfig(path, nil)
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want ErrConfigNotFound", err)
+		}
+	})
+}
+
+func TestUnmarshalErrorContent(t *testing.T) {
+	content := `{"Name": "app", "Port": "eighty"}`
+	path := writeFile(t, t.TempDir(), "config.json", content)
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Fatalf("got error %v, want an *UnmarshalError", err)
+	}
+
+	if unmarshalErr.Path != path {
+		t.Errorf("got path %q, want %q", unmarshalErr.Path, path)
+	}
+
+	if string(unmarshalErr.Content) != content {
+		t.Errorf("got content %q, want %q", unmarshalErr.Content, content)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("got error %v, want it to wrap the decoder error", err)
+	}
+}
+
+func TestNotFoundErrorMessage(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.toml"), filepath.Join(dir, "c.yaml")}
+
+	_, err := FindConfig("", paths)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	for _, p := range paths {
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("error %q does not mention %s", err, p)
+		}
+	}
+
+	if want := "could not locate configuration file in any of: " + strings.Join(paths, ", "); err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.json")
+	other := filepath.Join(dir, "other.json")
+	malformed := writeFile(t, dir, "malformed.json", "{\n  \"Name\": \"app\",\n  \"Port\": ,\n}\n")
+	mistyped := writeFile(t, dir, "mistyped.json", "{\n  \"Port\": \"80\"\n}\n")
+	valid := writeFile(t, dir, "valid.json", `{"Name": "app"}`)
+
+	read := func(path string, paths []string, finalize FinalizeFunc[*testConfig]) error {
+		var c testConfig
+
+		_, err := ReadConfig(path, paths, &c, JSON[testConfig](), finalize)
+		return err
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"missing primary", read(missing, nil, nil), "config file " + missing + " not found"},
+		{"missing fallbacks", read("", []string{missing, other}, nil), "config file not found; tried: " + missing + ", " + other},
+		{"no paths", read("", nil, nil), "config file not found"},
+		{"syntax", read(malformed, nil, nil), "invalid config in " + malformed + " at line 3, column 11: invalid character ',' looking for beginning of value"},
+		{"type", read(mistyped, nil, nil), "invalid config in " + mistyped + " at line 2, column 14: json: cannot unmarshal string into Go struct field testConfig.Port of type int"},
+		{"validation", read(valid, nil, CombineValidators(
+			func(*testConfig) error { return errors.New("port is required") },
+			func(*testConfig) error { return errors.New("name is reserved") },
+		)), "invalid config:\n  - port is required\n  - name is reserved"},
+		{"stage", &StageError{Stage: StageEnv, Err: errors.New("APP_PORT is not a number")}, "config env failed: APP_PORT is not a number"},
+		{"validate stage", &StageError{Stage: StageValidate, Err: errors.New("port is required")}, "invalid config: port is required"},
+		{"other", errors.New("boom"), "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatError(tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strings"
+)
+
+// execFields replaces the value of every string field of the struct v tagged
+// with `gonfig:"exec"` with the output of running it as a shell command.
+func execFields(v reflect.Value) error {
+	return walkFields(v, func(path []reflect.StructField, v reflect.Value) error {
+		if v.Kind() != reflect.String || !parseTag(path[len(path)-1]).has("exec") {
+			return nil
+		}
+
+		out, err := shellCommand(v.String()).Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+				err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+			}
+
+			return fmt.Errorf("unable to run command for %s: %w", fieldPath(path), err)
+		}
+
+		v.SetString(strings.TrimRight(string(out), "\r\n"))
+
+		return nil
+	})
+}
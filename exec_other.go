@@ -0,0 +1,10 @@
+//go:build !windows
+
+package gonfig
+
+import "os/exec"
+
+// shellCommand returns a command running command using sh.
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,53 @@
+package gonfig
+
+import "testing"
+
+type execConfig struct {
+	Name     string
+	Password string `gonfig:"exec"`
+}
+
+func TestAllowExec(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "echo no", "Password": "echo secret"}`)
+
+	var c execConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[execConfig](), nil, AllowExec())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (execConfig{Name: "echo no", Password: "secret"}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestAllowExecDisabled(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Password": "echo secret"}`)
+
+	var c execConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[execConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Password != "echo secret" {
+		t.Errorf("got password %q, want the command left as is", c.Password)
+	}
+}
+
+func TestAllowExecFailure(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Password": "exit 3"}`)
+
+	c := execConfig{Name: "initial"}
+
+	_, err := ReadConfig(path, nil, &c, JSON[execConfig](), nil, AllowExec())
+	if err == nil {
+		t.Fatal("got no error for a failing command")
+	}
+
+	if c != (execConfig{Name: "initial"}) {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+}
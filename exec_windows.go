@@ -0,0 +1,8 @@
+package gonfig
+
+import "os/exec"
+
+// shellCommand returns a command running command using cmd.exe.
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
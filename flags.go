@@ -0,0 +1,47 @@
+package gonfig
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+)
+
+// ApplyFlags overrides fields of the configuration object with the values of
+// command-line flags. It is meant to be called after the configuration file
+// has been read and ApplyEnv has been applied, so that the precedence is
+// defaults < file < environment < flags.
+//
+// A field is bound to a flag by a flag struct tag naming it, e.g.
+// `flag:"listen-addr"`. Only flags that were explicitly set on the command
+// line, as reported by fs.Visit, override the field, so that the defaults of
+// the flag set do not clobber values from the file.
+//
+// The string representation of the flag's value is parsed according to the
+// type of the field, which may be a string, boolean, integer, float,
+// time.Duration, a type implementing encoding.TextUnmarshaler, a pointer to
+// any of these, or a slice of any of these given as a comma-separated list.
+func ApplyFlags[T any](c *T, fs *flag.FlagSet) error {
+	set := make(map[string]*flag.Flag)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = f
+	})
+
+	return walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+		name := path[len(path)-1].Tag.Get("flag")
+		if name == "" || !isLeaf(v.Type()) {
+			return nil
+		}
+
+		f, ok := set[name]
+		if !ok {
+			return nil
+		}
+
+		err := setFromString(v, f.Value.String())
+		if err != nil {
+			return fmt.Errorf("unable to apply flag -%s: %w", name, err)
+		}
+
+		return nil
+	})
+}
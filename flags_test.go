@@ -0,0 +1,67 @@
+package gonfig
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestApplyFlags(t *testing.T) {
+	type server struct {
+		Debug bool `flag:"debug"`
+	}
+
+	type config struct {
+		Listen string `flag:"listen-addr"`
+		Port   int    `flag:"port"`
+		Server server
+	}
+
+	path := writeFile(t, t.TempDir(), "config.json", `{"Listen": "file:80", "Port": 80}`)
+
+	newFlagSet := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.String("listen-addr", "default:8080", "")
+		fs.Int("port", 8080, "")
+		fs.Bool("debug", false, "")
+
+		return fs
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want config
+	}{
+		{"unset", nil, config{Listen: "file:80", Port: 80}},
+		{"set", []string{"-listen-addr", "flag:443", "-debug"}, config{Listen: "flag:443", Port: 80, Server: server{Debug: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c config
+
+			_, err := ReadConfig(path, nil, &c, JSON[config](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fs := newFlagSet()
+
+			err = fs.Parse(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ApplyFlags(&c, fs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c != tt.want {
+				t.Errorf("got %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package gonfig
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Flatten returns the configuration object as a flat map from dotted keys to
+// string representations of the values, e.g. for support bundles or for
+// diffing configurations across environments.
+//
+// Keys are formed as described for DescribeConfig. Nested structs and
+// pointers to structs are flattened field by field, and so are the elements
+// of slices and arrays and the entries of maps, using their index or key as
+// the last segment, e.g. servers.0.port. Values are formatted using their
+// MarshalText method, if any, or the fmt package otherwise. The values of
+// fields tagged with `gonfig:"secret"` or `gonfig:"secretfile"` are replaced
+// with ****, and nil pointers are omitted.
+func Flatten[T any](c T) map[string]string {
+	m := map[string]string{}
+	flattenValue(m, "", reflect.ValueOf(&c).Elem())
+
+	return m
+}
+
+// flattenValue adds the entries for v, located at key, to m.
+func flattenValue(m map[string]string, key string, v reflect.Value) {
+	join := func(name string) string {
+		if key == "" {
+			return name
+		}
+
+		return key + "." + name
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	if v.CanInterface() {
+		tm, ok := v.Interface().(encoding.TextMarshaler)
+		if ok {
+			text, err := tm.MarshalText()
+			if err == nil {
+				m[key] = string(text)
+				return
+			}
+		}
+	}
+
+	switch {
+	case isStruct(v.Type()):
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			name := fieldKey(sf)
+			if name == "-" {
+				continue
+			}
+
+			if tag := parseTag(sf); tag.has("secret") || tag.has("secretfile") {
+				m[join(name)] = redactedValue
+				continue
+			}
+
+			flattenValue(m, join(name), v.Field(i))
+		}
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			m[key] = fmt.Sprint(v.Interface())
+			return
+		}
+
+		for i := range v.Len() {
+			flattenValue(m, join(strconv.Itoa(i)), v.Index(i))
+		}
+	case v.Kind() == reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			flattenValue(m, join(fmt.Sprint(iter.Key().Interface())), iter.Value())
+		}
+	default:
+		m[key] = fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,62 @@
+package gonfig
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestFlatten(t *testing.T) {
+	type server struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	type config struct {
+		Name     string `json:"name"`
+		Timeout  time.Duration
+		Deadline time.Time `json:"deadline"`
+		Database struct {
+			URL      string `json:"url"`
+			Password string `json:"password" gonfig:"secret"`
+		} `json:"database"`
+		Servers  []server       `json:"servers"`
+		Labels   map[string]int `json:"labels"`
+		Cache    *server        `json:"cache"`
+		Key      []byte         `json:"key"`
+		Ignored  string         `json:"-"`
+		internal string
+	}
+
+	c := config{
+		Name:     "app",
+		Timeout:  5 * time.Second,
+		Deadline: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Servers:  []server{{Host: "a", Port: 80}, {Host: "b", Port: 81}},
+		Labels:   map[string]int{"replicas": 3},
+		Key:      []byte{1, 2},
+		Ignored:  "ignored",
+		internal: "internal",
+	}
+	c.Database.URL = "postgres://db"
+	c.Database.Password = "hunter2"
+
+	want := map[string]string{
+		"name":              "app",
+		"Timeout":           "5s",
+		"deadline":          "2026-01-02T03:04:05Z",
+		"database.url":      "postgres://db",
+		"database.password": "****",
+		"servers.0.host":    "a",
+		"servers.0.port":    "80",
+		"servers.1.host":    "b",
+		"servers.1.port":    "81",
+		"labels.replicas":   "3",
+		"key":               "[1 2]",
+	}
+
+	got := Flatten(c)
+	if !maps.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
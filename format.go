@@ -0,0 +1,21 @@
+package gonfig
+
+// Format returns the canonical form of the configuration file at path, e.g.
+// for a "config fmt" command. The file is unmarshaled into a configuration
+// object using unmarshal, without validation, and marshaled again using
+// marshal, which normalizes key order, indentation, and quoting. Formatting
+// the result again yields the same content.
+//
+// Comments and keys that do not correspond to a field of the configuration
+// object are lost in the process, unless marshal preserves them, such as
+// MarshalTOML does for comment struct tags.
+func Format[T any](path string, unmarshal UnmarshalFunc[*T], marshal MarshalFunc[*T]) ([]byte, error) {
+	var c T
+
+	err := ReadFoundConfig(path, &c, unmarshal, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(&c)
+}
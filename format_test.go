@@ -0,0 +1,51 @@
+package gonfig
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+// testFormat formats the configuration file at path, checks that formatting
+// the result again yields identical content, and returns it.
+func testFormat[T any](t *testing.T, path string, unmarshal UnmarshalFunc[*T], marshal MarshalFunc[*T]) []byte {
+	t.Helper()
+
+	once, err := Format(path, unmarshal, marshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatted := writeFile(t, t.TempDir(), "formatted"+filepath.Ext(path), string(once))
+
+	twice, err := Format(formatted, unmarshal, marshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(once, twice) {
+		t.Errorf("formatting is not idempotent: got %q, then %q", once, twice)
+	}
+
+	return once
+}
+
+func TestFormat(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Port":8080,   "Unknown": true,
+"Name":"app"}`)
+
+	got := testFormat(t, path, JSON[testConfig](), MarshalJSON[testConfig]())
+
+	if want := "{\n  \"Name\": \"app\",\n  \"Port\": 8080\n}\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatMalformed(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{`)
+
+	_, err := Format(path, JSON[testConfig](), MarshalJSON[testConfig]())
+	if err == nil {
+		t.Error("got no error for malformed content")
+	}
+}
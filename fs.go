@@ -0,0 +1,53 @@
+package gonfig
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// ReadConfigFS is like ReadConfig, but locates and reads the configuration
+// file from fsys instead of the operating system's file system.
+//
+// Paths are interpreted according to the rules of io/fs, i.e. they must be
+// unrooted, slash-separated paths.
+func ReadConfigFS[T any](fsys fs.FS, path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) (string, error) {
+	var err error
+
+	path, err = FindConfigFS(fsys, path, searchPaths)
+	if err != nil {
+		return "", err
+	}
+
+	return path, ReadFoundConfigFS(fsys, path, c, unmarshal, finalize)
+}
+
+// ReadFoundConfigFS is like ReadFoundConfig, but reads the configuration file
+// from fsys instead of the operating system's file system.
+func ReadFoundConfigFS[T any](fsys fs.FS, path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return readConfig(path, f, c, unmarshal, finalize, nil)
+}
+
+// FindConfigFS is like FindConfig, but looks up the configuration file in
+// fsys instead of the operating system's file system. Glob patterns are
+// expanded using fs.Glob.
+func FindConfigFS(fsys fs.FS, path string, paths []string) (string, error) {
+	res, err := findConfig(finder{
+		stat: func(name string) (fs.FileInfo, error) {
+			return fs.Stat(fsys, name)
+		},
+		glob: func(pattern string) ([]string, error) {
+			return fs.Glob(fsys, pattern)
+		},
+	}, path, paths, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Path, nil
+}
@@ -0,0 +1,59 @@
+package gonfig
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadConfigFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"etc/app/config.json": {Data: []byte(`{"Name": "etc"}`)},
+		"home/config.json":    {Data: []byte(`{"Name": "home"}`)},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		searchPaths []string
+		wantPath    string
+		wantName    string
+	}{
+		{"primary", "etc/app/config.json", nil, "etc/app/config.json", "etc"},
+		{"first fallback", "", []string{"missing.json", "home/config.json", "etc/app/config.json"}, "home/config.json", "home"},
+		{"glob", "", []string{"etc/*/config.json"}, "etc/app/config.json", "etc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c testConfig
+
+			path, err := ReadConfigFS(fsys, tt.path, tt.searchPaths, &c, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if path != tt.wantPath {
+				t.Errorf("got path %q, want %q", path, tt.wantPath)
+			}
+
+			if c.Name != tt.wantName {
+				t.Errorf("got name %q, want %q", c.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFindConfigFSNotFound(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	_, err := FindConfigFS(fsys, "", []string{"config.json"})
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound", err)
+	}
+
+	_, err = FindConfigFS(fsys, "/abs/config.json", nil)
+	if err == nil {
+		t.Error("got no error for a rooted path")
+	}
+}
@@ -3,17 +3,44 @@
 package gonfig
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// StdinPath is the primary path that makes ReadConfig read the configuration
+// from standard input.
+const StdinPath = "-"
+
+// stdinName is the path reported for configuration read from standard input.
+const stdinName = "<stdin>"
+
 // UnmarshalFunc is a function that unmarshals raw bytes into a configuration
 // object of type T.
+//
+// Functions accepting an UnmarshalFunc treat nil as a no-op, leaving the
+// configuration object untouched.
 type UnmarshalFunc[T any] func([]byte, T) error
 
+// MarshalFunc is a function that marshals a configuration object of type T
+// into raw bytes.
+type MarshalFunc[T any] func(T) ([]byte, error)
+
 // FinalizeFunc is a function that validates a configuration object of type T
 // and returns an error if validation fails.
+//
+// Functions accepting a FinalizeFunc treat nil as a no-op, accepting any
+// configuration object.
 type FinalizeFunc[T any] func(T) error
 
 // ReadConfig reads a configuration file, unmarshals its content into the given
@@ -22,31 +49,407 @@ type FinalizeFunc[T any] func(T) error
 // If the primary path is empty, it searches for the configuration file in the
 // fallback paths. Returns the resolved path or an error if the file cannot be
 // located, read, unmarshaled, or validated.
-func ReadConfig[T any](path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) (string, error) {
+//
+// If the primary path is StdinPath, the configuration is read from standard
+// input instead and the returned path is "<stdin>".
+func ReadConfig[T any](path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, error) {
 	var err error
 
-	path, err = FindConfig(path, searchPaths)
+	o := newOptions(opts)
+
+	if path == StdinPath {
+		return stdinName, readConfig(stdinName, os.Stdin, c, unmarshal, finalize, o)
+	}
+
+	if path != "" {
+		return readPrimaryConfig(path, c, unmarshal, finalize, o)
+	}
+
+	path, err = FindConfig(path, searchPaths, opts...)
 	if err != nil {
 		return "", err
 	}
 
-	return path, ReadFoundConfig(path, c, unmarshal, finalize)
+	return path, readFoundConfig(path, c, unmarshal, finalize, o)
+}
+
+// readPrimaryConfig reads the configuration file at the primary path like
+// FindConfig followed by ReadFoundConfig, but opens the file only once and
+// uses the handle both for the existence check and for reading. Returns the
+// path unless the file could not be located, as ReadConfig does.
+func readPrimaryConfig[T any](path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], o *options) (string, error) {
+	path, err := o.resolve(path)
+	if err != nil {
+		return "", err
+	}
+
+	o.debug("trying configuration file", "path", path)
+
+	// Opening without blocking keeps FIFOs from stalling before they are
+	// rejected as non-regular files.
+	f, err := os.OpenFile(path, os.O_RDONLY|openFlags, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Report the stat error, as FindConfig does.
+		_, serr := os.Stat(path)
+		if serr != nil {
+			err = serr
+		}
+
+		return "", notFound(osFinder, path, err)
+	}
+	if err != nil {
+		// Report the failure as FindConfig followed by ReadFoundConfig
+		// would: stat failures, e.g. an inaccessible directory, fail to
+		// locate the file, while the file itself failing to open fails to
+		// read it.
+		info, serr := os.Stat(path)
+		if serr != nil {
+			return "", fmt.Errorf("could not stat configuration file %s: %w", path, serr)
+		}
+
+		serr = checkRegular(path, info)
+		if serr != nil {
+			return "", serr
+		}
+
+		return path, fmt.Errorf("unable to read configuration file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("could not stat configuration file %s: %w", path, err)
+	}
+
+	err = checkRegular(path, info)
+	if err != nil {
+		return "", err
+	}
+
+	o.debug("selected configuration file", "path", path)
+
+	err = checkPerms(path, info, o)
+	if err != nil {
+		return path, err
+	}
+
+	return path, readConfig(path, f, c, unmarshal, finalize, o.forFile())
+}
+
+// CheckConfig locates, reads, unmarshals, and validates a configuration file
+// like ReadConfig, but discards the configuration object. This is useful for
+// checking a configuration file without otherwise acting on it.
+//
+// Returns the resolved path, so that callers can report which file was
+// checked, or an error describing the problem.
+func CheckConfig[T any](path string, searchPaths []string, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, error) {
+	var c T
+
+	return ReadConfig(path, searchPaths, &c, unmarshal, finalize, opts...)
+}
+
+// ReadConfigRaw is like ReadConfig, but unmarshals the configuration file
+// into a generic map rather than a predefined struct, e.g. for a generic
+// validator or pretty-printer inspecting arbitrary configuration. The
+// configuration file is located as usual. Nested tables or objects are
+// represented as nested maps as produced by unmarshal, e.g.
+// JSON[map[string]any]().
+//
+// Returns the map and the resolved path.
+func ReadConfigRaw(path string, searchPaths []string, unmarshal func([]byte, *map[string]any) error, opts ...Option) (map[string]any, string, error) {
+	var m map[string]any
+
+	path, err := ReadConfig(path, searchPaths, &m, unmarshal, nil, opts...)
+	if err != nil {
+		return nil, path, err
+	}
+
+	return m, path, nil
 }
 
 // ReadFoundConfig reads and processes a configuration file from a known path.
 //
 // Unmarshals the file's content into the given configuration object and
-// validates it. Returns an error if the file cannot be read, unmarshaled, or
-// validated.
-func ReadFoundConfig[T any](path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
-	content, err := os.ReadFile(path)
+// validates it. The content is unmarshaled into a zero value of type T, which
+// only replaces *c if unmarshaling and validation succeed, so that c is left
+// untouched on failure. Gzip-compressed files, as indicated by a .gz
+// extension or the gzip header, are decompressed transparently. Returns an
+// error if the file cannot be read, decompressed, unmarshaled, or validated.
+// Unmarshaling errors are reported as *UnmarshalError.
+func ReadFoundConfig[T any](path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	return readFoundConfig(path, c, unmarshal, finalize, newOptions(opts))
+}
+
+// readFoundConfig implements ReadFoundConfig.
+func readFoundConfig[T any](path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], o *options) error {
+	content, err := readFoundContent(path, o)
+	if err != nil {
+		return err
+	}
+
+	return processConfig(path, content, c, unmarshal, finalize, o.forFile())
+}
+
+// readFoundContent opens the configuration file at path, checks its
+// permissions, and reads its content, as ReadFoundConfig does before
+// processing it.
+func readFoundContent(path string, o *options) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read configuration file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("could not stat configuration file %s: %w", path, err)
+	}
+
+	err = checkPerms(path, info, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return readContent(path, f, o)
+}
+
+// ReadFoundConfigWith is like ReadFoundConfig, but uses readFile instead of
+// opening and reading the file at path. This allows injecting content and
+// errors such as interrupted or partial reads, e.g. in tests. Errors returned
+// by readFile are wrapped with the path. Since no file is opened, the
+// permission check of RequireSecurePerms is skipped, and so is the local
+// override file of WithLocalOverride.
+func ReadFoundConfigWith[T any](readFile func(string) ([]byte, error), path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	o := newOptions(opts)
+
+	content, err := readFile(path)
 	if err != nil {
 		return fmt.Errorf("unable to read configuration file %s: %w", path, err)
 	}
 
-	err = unmarshal(content, c)
+	return processConfig(path, content, c, unmarshal, finalize, o)
+}
+
+// ReadConfigFile reads a configuration from an already opened file,
+// unmarshals its content into the given configuration object, and validates
+// it. The file's name is used in error messages. The file is not closed.
+//
+// This avoids reopening a file that the caller has already opened, e.g. to
+// lock it or to stat it first.
+func ReadConfigFile[T any](f *os.File, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	return readConfig(f.Name(), f, c, unmarshal, finalize, nil)
+}
+
+// ReadConfigReader reads a configuration from r, unmarshals its content into
+// the given configuration object, and validates it.
+//
+// Returns an error if the content cannot be read, unmarshaled, or validated.
+func ReadConfigReader[T any](r io.Reader, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	return readConfig("", r, c, unmarshal, finalize, nil)
+}
+
+// ReadConfigBytes unmarshals content into the given configuration object and
+// validates it without any I/O, e.g. for configuration generated by a program
+// or inlined in a test. As with the other read functions, gzip-compressed
+// content is decompressed and c is left untouched on failure.
+//
+// Returns an error if the content cannot be unmarshaled or validated.
+func ReadConfigBytes[T any](content []byte, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	return processConfig("", content, c, unmarshal, finalize, newOptions(opts))
+}
+
+// readConfig reads all content from r and processes it. The path of the
+// configuration file is used in error messages and may be empty if the
+// content does not originate from a file.
+func readConfig[T any](path string, r io.Reader, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], o *options) error {
+	content, err := readContent(path, r, o)
+	if err != nil {
+		return err
+	}
+
+	return processConfig(path, content, c, unmarshal, finalize, o)
+}
+
+// readContent reads all content from r, logging how much was read in how
+// much time. The path is used as described for readConfig.
+func readContent(path string, r io.Reader, o *options) ([]byte, error) {
+	start := time.Now()
+
+	content, err := readAll(r, o)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", describe(path), err)
+	}
+
+	o.debug("read configuration", "path", path, "bytes", len(content), "duration", time.Since(start))
+
+	return content, nil
+}
+
+// processConfig unmarshals content into the given configuration object and
+// validates it. Gzip-compressed content is decompressed first, a leading
+// UTF-8 byte order mark is removed, and encrypted content is decrypted if
+// enabled using WithDecrypt. The path of the configuration file is
+// used in error messages and may be empty if the content does not originate
+// from a file.
+//
+// The content is unmarshaled into a zero value of type T, which only replaces
+// *c if both unmarshaling and validation succeed, so that c is never left
+// partially populated, unless validation errors are turned into warnings
+// using WarnOnInvalid.
+func processConfig[T any](path string, content []byte, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], o *options) error {
+	content, err := prepareContent(path, content, o)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalConfig(path, content, c, unmarshal, finalize, o)
+}
+
+// unmarshalConfig is like processConfig, but expects content to be prepared
+// using prepareContent already.
+func unmarshalConfig[T any](path string, content []byte, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], o *options) error {
+	var err error
+
+	if unmarshal == nil {
+		return o.invalid(finalizeConfig(c, finalize))
+	}
+
+	var next T
+
+	if o != nil && o.allowEmpty && len(bytes.TrimSpace(content)) == 0 {
+		err = ApplyDefaults(&next)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = unmarshal(content, &next)
+		if err != nil {
+			line, column := position(err, content)
+
+			return &UnmarshalError{Path: path, Content: content, Line: line, Column: column, Err: err}
+		}
+	}
+
+	if o != nil && o.localOverride && o.localFile {
+		err = mergeLocalOverride(path, &next, unmarshal, o)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = finishConfig(&next, finalize, o)
+	if err != nil {
+		return err
+	}
+
+	*c = next
+
+	return nil
+}
+
+// prepareContent turns raw content into the content to unmarshal as
+// described for processConfig: it is decompressed, stripped of a leading byte
+// order mark, decrypted, and checked to be valid UTF-8 if enabled.
+func prepareContent(path string, content []byte, o *options) ([]byte, error) {
+	content, err := decompress(path, content, o)
+	if err != nil {
+		return nil, err
+	}
+
+	content = StripBOM(content)
+
+	if o != nil && o.decrypt != nil && o.encrypted(content) {
+		content, err = o.decrypt(content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt %s: %w", describe(path), err)
+		}
+	}
+
+	if o != nil && o.requireUTF8 {
+		err = checkUTF8(path, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
+// finishConfig runs the commands of fields tagged with `gonfig:"exec"` if
+// enabled using AllowExec and validates the unmarshaled configuration object
+// c, as processConfig does.
+func finishConfig[T any](c *T, finalize FinalizeFunc[*T], o *options) error {
+	if o != nil && o.allowExec {
+		err := execFields(reflect.ValueOf(c).Elem())
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.invalid(finalizeConfig(c, finalize))
+}
+
+// checkUTF8 reports an error with the offset of the first invalid sequence if
+// content is not valid UTF-8.
+func checkUTF8(path string, content []byte) error {
+	for offset := 0; offset < len(content); {
+		r, size := utf8.DecodeRune(content[offset:])
+		if r == utf8.RuneError && size == 1 {
+			return fmt.Errorf("%s is not valid UTF-8: invalid sequence at byte offset %d", describe(path), offset)
+		}
+
+		offset += size
+	}
+
+	return nil
+}
+
+// utf8BOM is the UTF-8 encoded byte order mark.
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// StripBOM returns content without its leading UTF-8 byte order mark, which
+// editors on Windows tend to prepend and which most decoders reject. Content
+// without a byte order mark is returned unchanged. The read functions strip
+// the byte order mark themselves, so StripBOM is only needed for content
+// passed to an UnmarshalFunc by other means.
+func StripBOM(content []byte) []byte {
+	return bytes.TrimPrefix(content, utf8BOM)
+}
+
+// readAll reads all content from r, failing if it exceeds the maximum size.
+func readAll(r io.Reader, o *options) ([]byte, error) {
+	limit := o.limit()
+	if limit == 0 {
+		return io.ReadAll(r)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
 	if err != nil {
-		return fmt.Errorf("unable to unmarshal configuration file %s: %w", path, err)
+		return nil, err
+	}
+
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("content exceeds the maximum size of %d bytes", limit)
+	}
+
+	return content, nil
+}
+
+// readFile reads the file at path, failing if it exceeds the maximum size.
+func readFile(path string, o *options) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readAll(f, o)
+}
+
+// finalizeConfig calls finalize on c unless finalize is nil.
+func finalizeConfig[T any](c T, finalize FinalizeFunc[T]) error {
+	if finalize == nil {
+		return nil
 	}
 
 	return finalize(c)
@@ -54,32 +457,322 @@ func ReadFoundConfig[T any](path string, c *T, unmarshal UnmarshalFunc[*T], fina
 
 // FindConfig determines the path to the configuration file by using the
 // provided primary path or searching through a list of fallback paths if the
-// primary path is empty.
+// primary path is empty. The first existing fallback path wins. Fallback
+// paths that do not exist are skipped, while any other error, such as a
+// permission error, is returned.
 //
-// Returns the resolved path or an error if no valid file is found or if the
-// file is inaccessible.
-func FindConfig(path string, paths []string) (string, error) {
-	var err error
+// Fallback paths may contain glob patterns as understood by filepath.Match,
+// e.g. /etc/app/conf.d/*.toml. Their matches are tried in lexical order, and
+// a pattern without matches is skipped like a missing file.
+//
+// Relative paths are resolved against the current working directory, or
+// against the directory given using WithBaseDir.
+//
+// Returns the resolved path or an error if no valid file is found, if the
+// file is inaccessible, or if it is not a regular file. Symbolic links are
+// followed, so a link to a regular file is accepted. If no file is found, the
+// error is a *NotFoundError matching ErrConfigNotFound.
+func FindConfig(path string, paths []string, opts ...Option) (string, error) {
+	res, err := findConfig(osFinder, path, paths, newOptions(opts))
+	if err != nil {
+		return "", err
+	}
 
-	if path == "" {
-		for _, p := range paths {
-			_, err = os.Stat(p)
+	return res.Path, nil
+}
+
+// FindConfigNames searches for a configuration file with any of the base
+// names in any of the directories, e.g. config.toml, .apprc, or app.conf. The
+// directories are tried in order, and within each directory the names are
+// tried in order, so that a directory earlier in dirs takes precedence
+// regardless of the name. Otherwise, the paths are handled like fallback
+// paths in FindConfig.
+func FindConfigNames(dirs []string, names []string, opts ...Option) (string, error) {
+	paths := make([]string, 0, len(dirs)*len(names))
+
+	for _, dir := range dirs {
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+
+	return FindConfig("", paths, opts...)
+}
+
+// FindConfigWith is like FindConfig, but uses stat instead of os.Stat to
+// check the primary path and the fallback paths. This allows injecting
+// behavior such as permission errors or files appearing concurrently, e.g. in
+// tests. Glob patterns are still expanded against the operating system's file
+// system.
+func FindConfigWith(stat func(string) (os.FileInfo, error), path string, paths []string, opts ...Option) (string, error) {
+	res, err := findConfig(finder{stat: stat, glob: filepath.Glob}, path, paths, newOptions(opts))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Path, nil
+}
+
+// SelectionPolicy determines which of multiple existing fallback paths is
+// selected.
+type SelectionPolicy int
+
+const (
+	// FirstMatch selects the first existing fallback path. This is the
+	// default.
+	FirstMatch SelectionPolicy = iota
+	// LastMatch selects the last existing fallback path, e.g. to let later
+	// paths override earlier system defaults.
+	LastMatch
+	// UniqueMatch selects the only existing fallback path and reports an
+	// error listing all of them if more than one exists.
+	UniqueMatch
+)
+
+// FindConfigWithPolicy is like FindConfig, but selects among multiple
+// existing fallback paths according to policy.
+func FindConfigWithPolicy(path string, paths []string, policy SelectionPolicy, opts ...Option) (string, error) {
+	return FindConfig(path, paths, append(opts, WithSelectionPolicy(policy))...)
+}
+
+// FindConfigStrict is like FindConfig, but reports an error listing all
+// existing fallback paths if more than one of them exists, rather than
+// silently selecting one, since a configuration file present in several
+// locations at once is likely a mistake the operator should know about. It
+// is equivalent to FindConfigWithPolicy with UniqueMatch.
+func FindConfigStrict(path string, paths []string, opts ...Option) (string, error) {
+	return FindConfigWithPolicy(path, paths, UniqueMatch, opts...)
+}
+
+// FindConfigResult describes how a configuration file was located.
+type FindConfigResult struct {
+	// Path is the resolved path of the configuration file, or empty if none
+	// was found.
+	Path string
+	// Tried holds the paths that were checked in order, up to and including
+	// the resolved path. Glob patterns are replaced with their matches.
+	Tried []string
+	// Source reports whether Path is the primary path or a fallback path, or
+	// is Default if no configuration file was found.
+	Source Source
+	// RealPath is Path with all symbolic links resolved, e.g. to log that
+	// /etc/app/config.toml points to /etc/app/config.prod.toml. It equals
+	// Path if Path is not a symbolic link.
+	RealPath string
+}
+
+// Source describes where a configuration came from.
+type Source int
+
+const (
+	// Default reports that no configuration file was found, so that the
+	// configuration consists of default values.
+	Default Source = iota
+	// Primary reports that the configuration was read from the primary
+	// path.
+	Primary
+	// Fallback reports that the configuration was read from one of the
+	// fallback paths, e.g. a system-wide configuration file.
+	Fallback
+)
+
+func (s Source) String() string {
+	switch s {
+	case Default:
+		return "default"
+	case Primary:
+		return "primary"
+	case Fallback:
+		return "fallback"
+	default:
+		return "Source(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// FindConfigDetailed is like FindConfig, but also reports which paths were
+// checked, e.g. to show users where the configuration file was looked for.
+// The tried paths are reported even if an error is returned.
+func FindConfigDetailed(path string, paths []string, opts ...Option) (FindConfigResult, error) {
+	o := newOptions(opts)
+
+	res, err := findConfig(osFinder, path, paths, o)
+	if err != nil {
+		return res, err
+	}
+
+	res.RealPath, err = filepath.EvalSymlinks(res.Path)
+	if err != nil {
+		return res, fmt.Errorf("unable to resolve configuration file %s: %w", res.Path, err)
+	}
+
+	if res.RealPath != res.Path {
+		o.debug("resolved configuration file", "path", res.Path, "real_path", res.RealPath)
+	}
+
+	return res, nil
+}
+
+// finder provides the file system operations used to locate configuration
+// files.
+type finder struct {
+	stat func(string) (fs.FileInfo, error)
+	glob func(string) ([]string, error)
+	// lstat, if not nil, is used to detect dangling symbolic links.
+	lstat func(string) (fs.FileInfo, error)
+}
+
+// osFinder is the finder for the operating system's file system.
+var osFinder = finder{
+	stat:  os.Stat,
+	glob:  filepath.Glob,
+	lstat: os.Lstat,
+}
+
+// findConfig implements FindConfig on top of the given finder.
+func findConfig(f finder, path string, paths []string, o *options) (FindConfigResult, error) {
+	var res FindConfigResult
+
+	path, err := o.resolve(path)
+	if err != nil {
+		return res, err
+	}
+
+	if o != nil {
+		resolved := make([]string, len(paths))
+		for i, p := range paths {
+			resolved[i], err = o.resolve(p)
 			if err != nil {
+				return res, err
+			}
+		}
+
+		paths = resolved
+	}
+
+	if path == "" {
+		expanded, err := expandGlobs(f, paths)
+		if err != nil {
+			return res, err
+		}
+
+		var matches []string
+
+		for _, p := range expanded {
+			o.debug("trying configuration file", "path", p)
+			res.Tried = append(res.Tried, p)
+
+			info, err := f.stat(p)
+			if errors.Is(err, fs.ErrNotExist) {
 				continue
 			}
+			if err != nil {
+				return res, fmt.Errorf("could not stat configuration file %s: %w", p, err)
+			}
 
-			path = p
+			err = checkRegular(p, info)
+			if err != nil {
+				return res, err
+			}
+
+			res.Path = p
+
+			if o.selectionPolicy() == FirstMatch {
+				break
+			}
+
+			if !slices.Contains(matches, p) {
+				matches = append(matches, p)
+			}
 		}
 
-		if path == "" {
-			return "", errors.New("could not locate configuration file")
+		if o.selectionPolicy() == UniqueMatch && len(matches) > 1 {
+			res.Path = ""
+
+			return res, fmt.Errorf("ambiguous configuration file, found: %s", strings.Join(matches, ", "))
 		}
-	} else {
-		_, err = os.Stat(path)
+
+		if res.Path != "" {
+			o.debug("selected configuration file", "path", res.Path)
+			res.Source = Fallback
+
+			return res, nil
+		}
+
+		return res, &NotFoundError{Paths: paths}
+	}
+
+	o.debug("trying configuration file", "path", path)
+	res.Tried = append(res.Tried, path)
+
+	info, err := f.stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return res, notFound(f, path, err)
+	}
+	if err != nil {
+		return res, fmt.Errorf("could not stat configuration file %s: %w", path, err)
+	}
+
+	err = checkRegular(path, info)
+	if err != nil {
+		return res, err
+	}
+
+	o.debug("selected configuration file", "path", path)
+	res.Path = path
+	res.Source = Primary
+
+	return res, nil
+}
+
+// notFound returns the error for the primary path that does not exist
+// according to err, pointing out dangling symbolic links.
+func notFound(f finder, path string, err error) error {
+	if f.lstat != nil {
+		info, lerr := f.lstat(path)
+		if lerr == nil && info.Mode()&fs.ModeSymlink != 0 {
+			err = fmt.Errorf("dangling symbolic link: %w", err)
+		}
+	}
+
+	return &NotFoundError{Paths: []string{path}, Err: err}
+}
+
+// expandGlobs replaces the glob patterns in paths with their matches in
+// lexical order, keeping all other paths as they are.
+func expandGlobs(f finder, paths []string) ([]string, error) {
+	var expanded []string
+
+	for _, p := range paths {
+		if !isGlob(p) {
+			expanded = append(expanded, p)
+			continue
+		}
+
+		matches, err := f.glob(p)
 		if err != nil {
-			return "", fmt.Errorf("could not stat configuration file %s: %w", path, err)
+			return nil, fmt.Errorf("invalid configuration file pattern %s: %w", p, err)
 		}
+
+		expanded = append(expanded, matches...)
+	}
+
+	return expanded, nil
+}
+
+// isGlob reports whether path contains glob metacharacters.
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// checkRegular returns an error if info does not describe a regular file.
+func checkRegular(path string, info fs.FileInfo) error {
+	if info.IsDir() {
+		return fmt.Errorf("configuration file %s is a directory", path)
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("configuration file %s is not a regular file", path)
 	}
 
-	return path, nil
+	return nil
 }
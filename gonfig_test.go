@@ -0,0 +1,939 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type testConfig struct {
+	Name string
+	Port int
+}
+
+// writeFile writes content to the file name in dir and returns its path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// replaceStdin replaces os.Stdin with a pipe that yields content for the
+// duration of the test.
+func replaceStdin(t *testing.T, content string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		w.WriteString(content)
+		w.Close()
+	}()
+
+	stdin := os.Stdin
+	os.Stdin = r
+
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		r.Close()
+	})
+}
+
+func TestReadConfigPrimaryErrorsMatchFindConfig(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced")
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string) string
+	}{
+		{"inaccessible directory", func(t *testing.T, dir string) string {
+			sub := filepath.Join(dir, "sub")
+
+			err := os.Mkdir(sub, 0o755)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := writeFile(t, sub, "config.json", `{}`)
+
+			err = os.Chmod(sub, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chmod(sub, 0o755) })
+
+			return path
+		}},
+		{"unreadable file", func(t *testing.T, dir string) string {
+			path := writeFile(t, dir, "config.json", `{}`)
+
+			err := os.Chmod(path, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return path
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.setup(t, t.TempDir())
+
+			var c testConfig
+
+			wantPath, wantErr := FindConfig(path, nil)
+			if wantErr == nil {
+				wantErr = ReadFoundConfig(path, &c, JSON[testConfig](), nil)
+			}
+
+			gotPath, gotErr := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+
+			if gotPath != wantPath {
+				t.Errorf("got path %q, want %q", gotPath, wantPath)
+			}
+
+			if gotErr == nil || wantErr == nil || gotErr.Error() != wantErr.Error() {
+				t.Errorf("got error %v, want %v", gotErr, wantErr)
+			}
+		})
+	}
+}
+
+func TestFindConfigFirstMatchWins(t *testing.T) {
+	dir := t.TempDir()
+
+	missing := filepath.Join(dir, "missing.json")
+	first := writeFile(t, dir, "first.json", `{}`)
+	second := writeFile(t, dir, "second.json", `{}`)
+
+	got, err := FindConfig("", []string{missing, first, second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != first {
+		t.Errorf("FindConfig() = %q, want %q", got, first)
+	}
+}
+
+func TestFindConfigNames(t *testing.T) {
+	names := []string{"config.toml", ".apprc", "app.conf"}
+
+	user, system := t.TempDir(), t.TempDir()
+	rc := writeFile(t, user, ".apprc", ``)
+	writeFile(t, user, "app.conf", ``)
+	writeFile(t, system, "config.toml", ``)
+
+	got, err := FindConfigNames([]string{filepath.Join(user, "missing"), user, system}, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != rc {
+		t.Errorf("got %q, want %q", got, rc)
+	}
+
+	_, err = FindConfigNames([]string{t.TempDir()}, names)
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound", err)
+	}
+}
+
+func TestReadConfigReader(t *testing.T) {
+	var c testConfig
+
+	err := ReadConfigReader(strings.NewReader(`{"Name": "app", "Port": 8080}`), &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c != (testConfig{Name: "app", Port: 8080}) {
+		t.Errorf("got %+v", c)
+	}
+}
+
+func TestReadConfigBytes(t *testing.T) {
+	errInvalid := errors.New("invalid")
+
+	tests := []struct {
+		name     string
+		content  string
+		finalize FinalizeFunc[*testConfig]
+		want     testConfig
+		wantErr  bool
+	}{
+		{"valid", `{"Name": "app", "Port": 8080}`, nil, testConfig{Name: "app", Port: 8080}, false},
+		{"malformed", `{"Name": `, nil, testConfig{Name: "initial"}, true},
+		{"invalid", `{"Name": "app"}`, func(*testConfig) error { return errInvalid }, testConfig{Name: "initial"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig{Name: "initial"}
+
+			err := ReadConfigBytes([]byte(tt.content), &c, JSON[testConfig](), tt.finalize)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+
+			if c != tt.want {
+				t.Errorf("got %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigReaderStdin(t *testing.T) {
+	replaceStdin(t, `{"Name": "stdin"}`)
+
+	var c testConfig
+
+	err := ReadConfigReader(os.Stdin, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "stdin" {
+		t.Errorf("got name %q, want %q", c.Name, "stdin")
+	}
+}
+
+func TestFindConfigSurfacesPermissionErrors(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced")
+	}
+
+	dir := t.TempDir()
+
+	sub := filepath.Join(dir, "sub")
+
+	err := os.Mkdir(sub, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hidden := writeFile(t, sub, "config.json", `{}`)
+	fallback := writeFile(t, dir, "config.json", `{}`)
+
+	err = os.Chmod(sub, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(sub, 0o755) })
+
+	_, err = FindConfig("", []string{hidden, fallback})
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("got error %v, want a permission error", err)
+	}
+}
+
+func TestFindConfigWithPermissionError(t *testing.T) {
+	stat := func(name string) (os.FileInfo, error) {
+		if name == "/denied/config.json" {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrPermission}
+		}
+
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	_, err := FindConfigWith(stat, "", []string{"/missing/config.json", "/denied/config.json"})
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("got error %v, want a permission error", err)
+	}
+
+	if errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want it not to match ErrConfigNotFound", err)
+	}
+}
+
+func TestReadConfigNilFuncs(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "app"}`)
+
+	t.Run("nil finalize", func(t *testing.T) {
+		var c testConfig
+
+		_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Name != "app" {
+			t.Errorf("got name %q, want app", c.Name)
+		}
+	})
+
+	t.Run("nil unmarshal", func(t *testing.T) {
+		c := testConfig{Name: "initial"}
+
+		got, err := ReadConfig(path, nil, &c, nil, func(c *testConfig) error {
+			if c.Name != "initial" {
+				t.Errorf("got name %q, want the configuration object untouched", c.Name)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != path {
+			t.Errorf("got path %q, want %q", got, path)
+		}
+	})
+}
+
+func TestReadConfigStdin(t *testing.T) {
+	replaceStdin(t, `{"Name": "piped", "Port": 9000}`)
+
+	var c testConfig
+
+	path, err := ReadConfig(StdinPath, []string{"ignored.json"}, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "<stdin>" {
+		t.Errorf("got path %q, want <stdin>", path)
+	}
+
+	if c != (testConfig{Name: "piped", Port: 9000}) {
+		t.Errorf("got %+v", c)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	validate := func(c *testConfig) error {
+		if c.Port == 0 {
+			return errors.New("port is required")
+		}
+
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", `{"Port": 8080}`, ""},
+		{"unparseable", `{"Port": `, "unable to unmarshal"},
+		{"invalid", `{"Name": "app"}`, "port is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.name+".json", tt.content)
+
+			got, err := CheckConfig("", []string{path}, JSON[testConfig](), validate)
+			if got != path {
+				t.Errorf("got path %q, want %q", got, path)
+			}
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindConfigRegularFile(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.json", `{}`)
+
+	t.Run("directory", func(t *testing.T) {
+		for _, find := range []func() (string, error){
+			func() (string, error) { return FindConfig(dir, nil) },
+			func() (string, error) { return FindConfig("", []string{dir, file}) },
+		} {
+			_, err := find()
+			if err == nil || !strings.Contains(err.Error(), "is a directory") {
+				t.Errorf("got error %v, want a directory error", err)
+			}
+		}
+	})
+
+	t.Run("symlink to file", func(t *testing.T) {
+		link := filepath.Join(dir, "link.json")
+
+		err := os.Symlink(file, link)
+		if err != nil {
+			t.Skip(err)
+		}
+
+		got, err := FindConfig(link, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != link {
+			t.Errorf("got path %q, want %q", got, link)
+		}
+	})
+}
+
+func TestFindConfigGlob(t *testing.T) {
+	dir := t.TempDir()
+	confd := filepath.Join(dir, "conf.d")
+
+	err := os.Mkdir(confd, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := writeFile(t, confd, "b.json", `{}`)
+	a := writeFile(t, confd, "a.json", `{}`)
+	fallback := writeFile(t, dir, "config.json", `{}`)
+
+	t.Run("multiple matches", func(t *testing.T) {
+		res, err := FindConfigDetailed("", []string{filepath.Join(confd, "*.json"), fallback})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.Path != a {
+			t.Errorf("got path %q, want the lexically first match %q", res.Path, a)
+		}
+
+		got, err := FindConfigWithPolicy("", []string{filepath.Join(confd, "*.json")}, LastMatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != b {
+			t.Errorf("got path %q, want %q", got, b)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		got, err := FindConfig("", []string{filepath.Join(confd, "*.toml"), fallback})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != fallback {
+			t.Errorf("got path %q, want %q", got, fallback)
+		}
+
+		_, err = FindConfig("", []string{filepath.Join(confd, "*.toml")})
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want ErrConfigNotFound", err)
+		}
+	})
+}
+
+func TestReadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("valid", func(t *testing.T) {
+		f, err := os.Open(writeFile(t, dir, "valid.json", `{"Name": "app"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		var c testConfig
+
+		err = ReadConfigFile(f, &c, JSON[testConfig](), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Name != "app" {
+			t.Errorf("got name %q, want app", c.Name)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		path := writeFile(t, dir, "malformed.json", `{`)
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		var c testConfig
+
+		err = ReadConfigFile(f, &c, JSON[testConfig](), nil)
+		if err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("got error %v, want one referencing %s", err, path)
+		}
+	})
+}
+
+func TestFindConfigDetailedTried(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.json")
+	b := writeFile(t, dir, "b.json", `{}`)
+	c := writeFile(t, dir, "c.json", `{}`)
+
+	res, err := FindConfigDetailed("", []string{a, b, c})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Path != b || res.Source != Fallback {
+		t.Errorf("got path %q from %v, want %q from a fallback", res.Path, res.Source, b)
+	}
+
+	if want := []string{a, b}; !slices.Equal(res.Tried, want) {
+		t.Errorf("got tried %q, want %q", res.Tried, want)
+	}
+
+	res, err = FindConfigDetailed("", []string{a})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	if want := []string{a}; !slices.Equal(res.Tried, want) {
+		t.Errorf("got tried %q on error, want %q", res.Tried, want)
+	}
+
+	res, err = FindConfigDetailed(c, []string{a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Source != Primary || !slices.Equal(res.Tried, []string{c}) {
+		t.Errorf("got %+v, want only the primary path tried", res)
+	}
+}
+
+func TestFindConfigDetailedSymlink(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.prod.json", `{}`)
+	link := filepath.Join(dir, "config.json")
+
+	err := os.Symlink(file, link)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	want, err := filepath.EvalSymlinks(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := FindConfigDetailed(link, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Path != link || res.RealPath != want {
+		t.Errorf("got path %q resolved to %q, want %q resolved to %q", res.Path, res.RealPath, link, want)
+	}
+
+	res, err = FindConfigDetailed(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.RealPath != want {
+		t.Errorf("got real path %q for a regular file, want %q", res.RealPath, want)
+	}
+}
+
+func TestFindConfigDanglingSymlink(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "config.json")
+
+	err := os.Symlink(filepath.Join(dir, "missing.json"), link)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	for _, read := range []func() error{
+		func() error { _, err := FindConfig(link, nil); return err },
+		func() error { _, err := FindConfigDetailed(link, nil); return err },
+		func() error { _, err := ReadConfig(link, nil, &testConfig{}, JSON[testConfig](), nil); return err },
+	} {
+		err := read()
+		if err == nil || !strings.Contains(err.Error(), "dangling symbolic link") {
+			t.Errorf("got error %v, want a dangling symbolic link error", err)
+		}
+
+		if !errors.Is(err, ErrConfigNotFound) || !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("got error %v, want it to match ErrConfigNotFound and fs.ErrNotExist", err)
+		}
+	}
+}
+
+func TestReadConfigPreservesValueOnFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		content  string
+		finalize FinalizeFunc[*testConfig]
+	}{
+		// encoding/json sets Name before failing on Port.
+		{"unmarshal", `{"Name": "partial", "Port": "eighty"}`, nil},
+		{"validation", `{"Name": "partial", "Port": 1}`, func(*testConfig) error { return errors.New("invalid") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.name+".json", tt.content)
+
+			c := testConfig{Name: "initial", Port: 8080}
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), tt.finalize)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			if c != (testConfig{Name: "initial", Port: 8080}) {
+				t.Errorf("got %+v, want the configuration object unchanged", c)
+			}
+		})
+	}
+}
+
+func TestFindConfigWithPolicy(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.json")
+	system := writeFile(t, dir, "system.json", `{}`)
+	user := writeFile(t, dir, "user.json", `{}`)
+
+	tests := []struct {
+		policy SelectionPolicy
+		want   string
+	}{
+		{FirstMatch, system},
+		{LastMatch, user},
+	}
+
+	for _, tt := range tests {
+		got, err := FindConfigWithPolicy("", []string{system, missing, user, missing}, tt.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Errorf("policy %d: got path %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+
+	got, err := FindConfig("", []string{system, user})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != system {
+		t.Errorf("got path %q by default, want %q", got, system)
+	}
+}
+
+func TestFindConfigStrict(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.json")
+	system := writeFile(t, dir, "system.json", `{}`)
+	user := writeFile(t, dir, "user.json", `{}`)
+
+	got, err := FindConfigStrict("", []string{missing, user, user})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != user {
+		t.Errorf("got path %q, want %q", got, user)
+	}
+
+	got, err = FindConfigStrict("", []string{system, missing, user})
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || !strings.Contains(err.Error(), system+", "+user) {
+		t.Errorf("got path %q and error %v, want an error listing both matches", got, err)
+	}
+
+	_, err = FindConfigStrict("", []string{missing})
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound", err)
+	}
+
+	got, err = FindConfigStrict(user, []string{system, user})
+	if err != nil || got != user {
+		t.Errorf("got path %q and error %v, want the primary path %q", got, err, user)
+	}
+}
+
+// fakeFileInfo describes a regular file for stat functions injected in
+// tests.
+type fakeFileInfo struct {
+	os.FileInfo
+}
+
+func (fakeFileInfo) IsDir() bool       { return false }
+func (fakeFileInfo) Mode() os.FileMode { return 0o644 }
+
+func TestFindConfigWith(t *testing.T) {
+	t.Run("primary permission error", func(t *testing.T) {
+		stat := func(name string) (os.FileInfo, error) {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrPermission}
+		}
+
+		_, err := FindConfigWith(stat, "/etc/app/config.json", nil)
+		if !errors.Is(err, fs.ErrPermission) || errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want a permission error", err)
+		}
+	})
+
+	t.Run("file appearing", func(t *testing.T) {
+		var calls []string
+
+		stat := func(name string) (os.FileInfo, error) {
+			calls = append(calls, name)
+
+			// The file appears after it was first checked for.
+			if name == "/etc/app/config.json" && len(calls) > 2 {
+				return fakeFileInfo{}, nil
+			}
+
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+		}
+
+		paths := []string{"/etc/app/config.json", "/usr/share/app/config.json"}
+
+		_, err := FindConfigWith(stat, "", paths)
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Fatalf("got error %v, want ErrConfigNotFound", err)
+		}
+
+		got, err := FindConfigWith(stat, "", paths)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != paths[0] {
+			t.Errorf("got path %q, want %q", got, paths[0])
+		}
+	})
+}
+
+func TestReadConfigPrimaryMatchesFindConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing", filepath.Join(dir, "missing.json")},
+		{"directory", dir},
+		{"malformed", writeFile(t, dir, "malformed.json", `{`)},
+		{"valid", writeFile(t, dir, "valid.json", `{"Name": "app"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want, got testConfig
+
+			wantPath, wantErr := FindConfig(tt.path, nil)
+			if wantErr == nil {
+				wantErr = ReadFoundConfig(wantPath, &want, JSON[testConfig](), nil)
+			}
+
+			gotPath, gotErr := ReadConfig(tt.path, nil, &got, JSON[testConfig](), nil)
+
+			if gotPath != wantPath {
+				t.Errorf("got path %q, want %q", gotPath, wantPath)
+			}
+
+			if fmt.Sprint(gotErr) != fmt.Sprint(wantErr) {
+				t.Errorf("got error %v, want %v", gotErr, wantErr)
+			}
+
+			if errors.Is(gotErr, ErrConfigNotFound) != errors.Is(wantErr, ErrConfigNotFound) {
+				t.Errorf("got error %v, want ErrConfigNotFound to match as for %v", gotErr, wantErr)
+			}
+
+			if got != want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadFoundConfigWith(t *testing.T) {
+	errRead := errors.New("interrupted system call")
+
+	tests := []struct {
+		name    string
+		read    func(string) ([]byte, error)
+		want    testConfig
+		wantErr error
+	}{
+		{"content", func(string) ([]byte, error) { return []byte(`{"Name": "app", "Port": 8080}`), nil }, testConfig{Name: "app", Port: 8080}, nil},
+		{"error", func(string) ([]byte, error) { return nil, errRead }, testConfig{Name: "initial"}, errRead},
+		{"partial read", func(string) ([]byte, error) { return []byte(`{"Name": "ap`), nil }, testConfig{Name: "initial"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+
+			c := testConfig{Name: "initial"}
+
+			err := ReadFoundConfigWith(func(path string) ([]byte, error) {
+				gotPath = path
+				return tt.read(path)
+			}, "/etc/app/config.json", &c, JSON[testConfig](), nil)
+
+			if gotPath != "/etc/app/config.json" {
+				t.Errorf("got read path %q, want /etc/app/config.json", gotPath)
+			}
+
+			if c != tt.want {
+				t.Errorf("got %+v, want %+v", c, tt.want)
+			}
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), "/etc/app/config.json") {
+					t.Errorf("got error %v, want it to wrap %v with the path", err, tt.wantErr)
+				}
+			case tt.want.Name == "initial":
+				var unmarshalErr *UnmarshalError
+				if !errors.As(err, &unmarshalErr) {
+					t.Errorf("got error %v, want an *UnmarshalError", err)
+				}
+			case err != nil:
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReadConfigBOM(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", "\uFEFF"+`{"Name": "app", "Port": 8080}`)
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (testConfig{Name: "app", Port: 8080}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestStripBOM(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"with BOM", "\uFEFF{}", "{}"},
+		{"without BOM", "{}", "{}"},
+		{"BOM not leading", "{}\uFEFF", "{}\uFEFF"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripBOM([]byte(tt.content)); string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigRaw(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"name":"app","server":{"host":"localhost","port":8080},"tags":["a","b"]}`)
+
+	m, got, err := ReadConfigRaw("", []string{filepath.Join(dir, "missing.json"), path}, JSON[map[string]any]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != path {
+		t.Errorf("got path %q, want %q", got, path)
+	}
+
+	server, ok := m["server"].(map[string]any)
+	if !ok {
+		t.Fatalf("got server %#v, want a nested map", m["server"])
+	}
+
+	if m["name"] != "app" || server["host"] != "localhost" || server["port"] != float64(8080) {
+		t.Errorf("got %#v", m)
+	}
+
+	if tags, ok := m["tags"].([]any); !ok || !slices.Equal(tags, []any{"a", "b"}) {
+		t.Errorf("got tags %#v, want [a b]", m["tags"])
+	}
+
+	malformed := writeFile(t, dir, "malformed.json", `{"name":`)
+
+	m, _, err = ReadConfigRaw(malformed, nil, JSON[map[string]any]())
+	if err == nil || m != nil {
+		t.Errorf("got %#v and error %v, want no map and an error", m, err)
+	}
+}
+
+func BenchmarkReadConfigPrimary(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "config.json")
+
+	err := os.WriteFile(path, []byte(`{"Name": "app", "Port": 8080}`), 0o644)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("ReadConfig", func(b *testing.B) {
+		for range b.N {
+			var c testConfig
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// FindConfig followed by ReadFoundConfig stats the file separately
+	// before opening it.
+	b.Run("FindConfig+ReadFoundConfig", func(b *testing.B) {
+		for range b.N {
+			var c testConfig
+
+			found, err := FindConfig(path, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			err = ReadFoundConfig(found, &c, JSON[testConfig](), nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
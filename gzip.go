@@ -0,0 +1,39 @@
+package gonfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// gzipMagic is the header of gzip-compressed content.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isGzip reports whether the configuration file at path with the given
+// content is gzip-compressed, as indicated by a .gz extension or by the gzip
+// header.
+func isGzip(path string, content []byte) bool {
+	return strings.EqualFold(filepath.Ext(path), ".gz") || bytes.HasPrefix(content, gzipMagic)
+}
+
+// decompress returns the decompressed content of the configuration file at
+// path if it is gzip-compressed, or content as is otherwise.
+func decompress(path string, content []byte, o *options) ([]byte, error) {
+	if !isGzip(path, content) {
+		return content, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress %s: %w", describe(path), err)
+	}
+
+	content, err = readAll(r, o)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress %s: %w", describe(path), err)
+	}
+
+	return content, nil
+}
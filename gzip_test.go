@@ -0,0 +1,71 @@
+package gonfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// gzipContent returns content compressed using gzip.
+func gzipContent(t *testing.T, content string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+
+	_, err := zw.Write([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.String()
+}
+
+func TestReadConfigGzip(t *testing.T) {
+	dir := t.TempDir()
+	compressed := gzipContent(t, `{"Name": "gzipped"}`)
+
+	// Compressed content is detected by its extension as well as by its
+	// magic bytes.
+	for _, name := range []string{"config.json.gz", "config.json"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, dir, name, compressed)
+
+			var c testConfig
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Name != "gzipped" {
+				t.Errorf("got name %q, want gzipped", c.Name)
+			}
+		})
+	}
+}
+
+func TestReadConfigGzipCorrupt(t *testing.T) {
+	compressed := gzipContent(t, `{"Name": "gzipped"}`)
+	path := writeFile(t, t.TempDir(), "config.json.gz", compressed[:len(compressed)/2])
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+	if err == nil || !strings.Contains(err.Error(), "unable to decompress") {
+		t.Fatalf("got error %v, want a decompression error", err)
+	}
+
+	var unmarshalErr *UnmarshalError
+	if errors.As(err, &unmarshalErr) {
+		t.Errorf("got error %v, want it to be distinct from unmarshal errors", err)
+	}
+}
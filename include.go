@@ -0,0 +1,110 @@
+package gonfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxIncludeDepth is the maximum nesting depth of included configuration
+// files.
+const maxIncludeDepth = 16
+
+// Includer is implemented by configuration objects that reference further
+// configuration files to include, e.g. through an include key.
+type Includer interface {
+	// Includes returns the paths of the configuration files to include.
+	// Relative paths are relative to the directory of the including file.
+	Includes() []string
+}
+
+// ReadConfigIncludes is like ReadConfig, but additionally reads the
+// configuration files included by the configuration object if it implements
+// Includer. Included files may include further files.
+//
+// Included files are unmarshaled into zero values of type T and merged in
+// order as if by Merge, with the including file overriding the files it
+// includes. The merged result is validated once and only replaces *c if
+// reading all files, merging, and validation succeed. Returns an error if the
+// includes form a cycle or are nested more than 16 levels deep.
+func ReadConfigIncludes[T any](path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) (string, error) {
+	var err error
+
+	path, err = FindConfig(path, searchPaths)
+	if err != nil {
+		return "", err
+	}
+
+	next := *c
+	copyPointers(reflect.ValueOf(&next).Elem())
+
+	err = readIncludes(path, &next, unmarshal, nil)
+	if err != nil {
+		return path, err
+	}
+
+	err = finalizeConfig(&next, finalize)
+	if err != nil {
+		return path, err
+	}
+
+	*c = next
+
+	return path, nil
+}
+
+// readIncludes reads the configuration file at path along with the files it
+// includes and merges the result into c. The stack holds the absolute paths
+// of the including files.
+func readIncludes[T any](path string, c *T, unmarshal UnmarshalFunc[*T], stack []string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("unable to resolve configuration file %s: %w", path, err)
+	}
+
+	if slices.Contains(stack, abs) {
+		return fmt.Errorf("configuration file include cycle: %s", strings.Join(append(stack, abs), " -> "))
+	}
+
+	if len(stack) >= maxIncludeDepth {
+		return fmt.Errorf("configuration file %s exceeds the maximum include depth of %d", path, maxIncludeDepth)
+	}
+
+	var layer T
+
+	content, err := readFile(path, nil)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration file %s: %w", path, err)
+	}
+
+	err = processConfig(path, content, &layer, unmarshal, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var merged T
+
+	if includer, ok := any(&layer).(Includer); ok {
+		for _, include := range includer.Includes() {
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+
+			var included T
+
+			err = readIncludes(include, &included, unmarshal, append(stack, abs))
+			if err != nil {
+				return err
+			}
+
+			Merge(&merged, &included)
+		}
+	}
+
+	Merge(&merged, &layer)
+	Merge(c, &merged)
+
+	return nil
+}
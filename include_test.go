@@ -0,0 +1,98 @@
+package gonfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type includeConfig struct {
+	Include []string
+	Name    string
+	Port    int
+}
+
+func (c *includeConfig) Includes() []string {
+	return c.Include
+}
+
+func TestReadConfigIncludesAllOrNothing(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.json":   `{"Include":["first.json","broken.json"]}`,
+		"first.json":  `{"Name":"first","Port":1}`,
+		"broken.json": `{`,
+	}
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := includeConfig{Name: "initial"}
+
+	_, err := ReadConfigIncludes(filepath.Join(dir, "main.json"), nil, &c, JSON[includeConfig](), nil)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	if c.Name != "initial" || c.Port != 0 {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+}
+
+func TestReadConfigIncludes(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.Mkdir(filepath.Join(dir, "conf.d"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := writeFile(t, dir, "db.json", `{"Name":"db","Port":5432}`)
+	writeFile(t, dir, "conf.d/port.json", `{"Port":8080}`)
+
+	tests := []struct {
+		name    string
+		content string
+		want    testConfig
+	}{
+		{"simple", `{"Include":["` + filepath.ToSlash(db) + `"],"Name":"main"}`, testConfig{"main", 5432}},
+		{"relative", `{"Include":["db.json","conf.d/port.json"]}`, testConfig{"db", 8080}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, "main.json", tt.content)
+
+			var c includeConfig
+
+			_, err := ReadConfigIncludes(path, nil, &c, JSON[includeConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := (testConfig{c.Name, c.Port}); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigIncludesCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	a := writeFile(t, dir, "a.json", `{"Include":["b.json"]}`)
+	writeFile(t, dir, "b.json", `{"Include":["a.json"]}`)
+
+	var c includeConfig
+
+	_, err := ReadConfigIncludes(a, nil, &c, JSON[includeConfig](), nil)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("got error %v, want an include cycle error", err)
+	}
+}
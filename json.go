@@ -0,0 +1,147 @@
+package gonfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// JSON returns an UnmarshalFunc that decodes JSON content using
+// encoding/json.
+func JSON[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		return json.Unmarshal(content, c)
+	}
+}
+
+// JSONStrict returns an UnmarshalFunc that decodes JSON content using
+// encoding/json and rejects keys that do not correspond to a field of the
+// configuration object. Due to encoding/json, only the first unexpected key is
+// reported.
+func JSONStrict[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+
+		err := dec.Decode(c)
+		if err != nil {
+			return err
+		}
+
+		if dec.More() {
+			return errors.New("invalid character after top-level value")
+		}
+
+		return nil
+	}
+}
+
+// MarshalJSON returns a MarshalFunc that encodes the configuration object as
+// indented JSON using encoding/json.
+func MarshalJSON[T any]() MarshalFunc[*T] {
+	return func(c *T) ([]byte, error) {
+		content, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return append(content, '\n'), nil
+	}
+}
+
+// JSONDecoder returns a NewDecoderFunc for streams of JSON values, such as
+// JSON Lines, using encoding/json.
+func JSONDecoder() NewDecoderFunc {
+	return func(r io.Reader) Decoder {
+		return json.NewDecoder(r)
+	}
+}
+
+// JSONC returns an UnmarshalFunc that decodes JSON with comments, as used
+// e.g. by VS Code. Line comments (//), block comments (/* */), and trailing
+// commas in objects and arrays are removed before decoding the content using
+// encoding/json. Comment markers inside strings are preserved.
+func JSONC[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		return json.Unmarshal(stripJSONC(content), c)
+	}
+}
+
+// stripJSONC replaces comments and trailing commas in content with spaces,
+// retaining newlines so that error positions remain accurate.
+func stripJSONC(content []byte) []byte {
+	out := bytes.Clone(content)
+
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if out[i] != '\n' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	// Remove comments.
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"':
+			i = skipJSONString(out, i)
+		case bytes.HasPrefix(out[i:], []byte("//")):
+			end := bytes.IndexByte(out[i:], '\n')
+			if end < 0 {
+				end = len(out) - i
+			}
+
+			blank(i, i+end)
+			i += end
+		case bytes.HasPrefix(out[i:], []byte("/*")):
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				// Leave unterminated comments for the decoder to report.
+				return out
+			}
+
+			blank(i, i+2+end+2)
+			i += 2 + end + 1
+		}
+	}
+
+	// Remove trailing commas.
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			i = skipJSONString(out, i)
+		case ',':
+			next := i + 1
+			for next < len(out) && isJSONSpace(out[next]) {
+				next++
+			}
+
+			if next < len(out) && (out[next] == '}' || out[next] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+
+	return out
+}
+
+// skipJSONString returns the index of the closing quote of the string
+// starting at index i, or the last index if the string is unterminated.
+func skipJSONString(content []byte, i int) int {
+	for i++; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return len(content) - 1
+}
+
+// isJSONSpace reports whether b is JSON whitespace.
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
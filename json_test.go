@@ -0,0 +1,132 @@
+package gonfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type nestedConfig struct {
+	Name     string
+	Server   nestedServer
+	Tags     []string
+	Backends map[string]nestedServer
+}
+
+type nestedServer struct {
+	Host string
+	Port int
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	want := nestedConfig{
+		Name:   "app",
+		Server: nestedServer{Host: "localhost", Port: 8080},
+		Tags:   []string{"a", "b"},
+		Backends: map[string]nestedServer{
+			"db": {Host: "db.internal", Port: 5432},
+		},
+	}
+
+	content, err := MarshalJSON[nestedConfig]()(&want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeFile(t, t.TempDir(), "config.json", string(content))
+
+	var got nestedConfig
+
+	_, err = ReadConfig(path, nil, &got, JSON[nestedConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestJSONMalformed(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", "{\n  \"Name\": \"app\",\n  \"Port\": oops\n}\n")
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Fatalf("got error %v, want an *UnmarshalError", err)
+	}
+
+	if !strings.Contains(err.Error(), path) {
+		t.Errorf("error %q does not mention %s", err, path)
+	}
+
+	if unmarshalErr.Line != 3 {
+		t.Errorf("got line %d, want 3", unmarshalErr.Line)
+	}
+}
+
+func TestJSONStrict(t *testing.T) {
+	var c testConfig
+
+	err := JSONStrict[testConfig]()([]byte(`{"Name": "app", "Bogus": 1}`), &c)
+	if err == nil || !strings.Contains(err.Error(), `"Bogus"`) {
+		t.Errorf("got error %v, want one naming the unknown key", err)
+	}
+
+	err = JSONStrict[testConfig]()([]byte(`{"Name": "app"}`), &c)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJSONC(t *testing.T) {
+	content := `{
+	// The name of the application.
+	"Name": "app // not a comment", /* inline */
+	/*
+	 * The port to listen on.
+	 */
+	"Port": 8080,
+}
+`
+
+	var c testConfig
+
+	err := JSONC[testConfig]()([]byte(content), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (testConfig{Name: "app // not a comment", Port: 8080}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"line comment", "{} // c", "{}     "},
+		{"block comment", "[1, /* c */ 2]", "[1,         2]"},
+		{"comment in string", `{"a": "/* x */ // y"}`, `{"a": "/* x */ // y"}`},
+		{"escaped quote", `{"a": "\" // y"}`, `{"a": "\" // y"}`},
+		{"trailing commas", "{\"a\": [1, 2,\n],\n}", "{\"a\": [1, 2 \n] \n}"},
+		{"comma in string", `["a,]"]`, `["a,]"]`},
+		{"newlines kept", "/* a\nb */{}", "    \n    {}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(stripJSONC([]byte(tt.content)))
+			if got != tt.want {
+				t.Errorf("stripJSONC(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+//go:build gonfig_jsonschema
+
+package gonfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// SchemaValidator returns a FinalizeFunc that validates the configuration
+// object against the given JSON Schema. The object is marshaled to JSON using
+// encoding/json beforehand, so the schema applies to its JSON representation.
+// Every violation is reported with the JSON pointer of the offending value,
+// and the errors of all violations are joined.
+//
+// Returns an error if the schema is invalid. Only available with the
+// gonfig_jsonschema build tag, which pulls in
+// github.com/santhosh-tekuri/jsonschema/v6.
+func SchemaValidator[T any](schema []byte) (FinalizeFunc[*T], error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	const url = "gonfig://schema.json"
+
+	compiler := jsonschema.NewCompiler()
+
+	err = compiler.AddResource(url, doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	sch, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	return func(c *T) error {
+		content, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("unable to marshal configuration for schema validation: %w", err)
+		}
+
+		v, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+		if err != nil {
+			return fmt.Errorf("unable to marshal configuration for schema validation: %w", err)
+		}
+
+		err = sch.Validate(v)
+
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+
+		var errs []error
+
+		for _, unit := range validationErr.BasicOutput().Errors {
+			if unit.Error == nil {
+				continue
+			}
+
+			location := unit.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+
+			errs = append(errs, fmt.Errorf("%s: %s", location, unit.Error))
+		}
+
+		if len(errs) == 0 {
+			return err
+		}
+
+		return errors.Join(errs...)
+	}, nil
+}
@@ -0,0 +1,56 @@
+//go:build gonfig_jsonschema
+
+package gonfig
+
+import (
+	"strings"
+	"testing"
+)
+
+type schemaConfig struct {
+	Name string `json:"name,omitempty"`
+	Port any    `json:"port"`
+}
+
+const testSchema = `{
+	"type": "object",
+	"required": ["name", "port"],
+	"properties": {
+		"name": {"type": "string"},
+		"port": {"type": "integer", "minimum": 1}
+	}
+}`
+
+func TestSchemaValidator(t *testing.T) {
+	validate, err := SchemaValidator[schemaConfig]([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = validate(&schemaConfig{Name: "app", Port: 8080})
+	if err != nil {
+		t.Errorf("got error %v for a valid configuration", err)
+	}
+
+	err = validate(&schemaConfig{Port: "eighty"})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	for _, want := range []string{"name", "/port:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	if n := strings.Count(err.Error(), "\n") + 1; n < 2 {
+		t.Errorf("got %d violations in %q, want both to be reported", n, err)
+	}
+}
+
+func TestSchemaValidatorInvalidSchema(t *testing.T) {
+	_, err := SchemaValidator[schemaConfig]([]byte(`{"type": 1}`))
+	if err == nil {
+		t.Error("got no error for an invalid schema")
+	}
+}
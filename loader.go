@@ -0,0 +1,155 @@
+package gonfig
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Loader holds the last valid configuration read from a configuration file.
+// It is safe for concurrent use.
+type Loader[T any] struct {
+	path      string
+	unmarshal UnmarshalFunc[*T]
+	finalize  FinalizeFunc[*T]
+	current   atomic.Pointer[T]
+
+	successes atomic.Uint64
+	failures  atomic.Uint64
+	lastGood  atomic.Int64
+}
+
+// LoaderStats describes the reloads performed by a Loader, e.g. to expose
+// them as metrics and alert on configuration problems.
+type LoaderStats struct {
+	// Successes is the number of successful loads, including the initial
+	// one.
+	Successes uint64
+	// Failures is the number of failed reloads.
+	Failures uint64
+	// LastGood is the time of the last successful load.
+	LastGood time.Time
+}
+
+// NewLoader returns a Loader for the configuration file at path and performs
+// the initial load. Returns an error if the initial load fails.
+func NewLoader[T any](path string, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) (*Loader[T], error) {
+	l := &Loader[T]{
+		path:      path,
+		unmarshal: unmarshal,
+		finalize:  finalize,
+	}
+
+	_, err := l.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Current returns the last valid configuration object.
+func (l *Loader[T]) Current() T {
+	return *l.current.Load()
+}
+
+// Stats returns the statistics of the reloads performed so far. Each value is
+// read atomically, but a reload running concurrently may be reflected in some
+// of them only.
+func (l *Loader[T]) Stats() LoaderStats {
+	stats := LoaderStats{
+		Successes: l.successes.Load(),
+		Failures:  l.failures.Load(),
+	}
+
+	if ns := l.lastGood.Load(); ns != 0 {
+		stats.LastGood = time.Unix(0, ns)
+	}
+
+	return stats
+}
+
+// Reload re-reads the configuration file into a zero value of type T and
+// makes it the current configuration object if both unmarshaling and
+// validation succeed. Otherwise the previous configuration object is kept and
+// the error is returned.
+//
+// Returns the changes from the previous to the new configuration object as
+// reported by Diff, so that callers can take targeted action, e.g. only
+// restart a listener if its address changed. The changes are empty if nothing
+// changed and nil for the initial load.
+func (l *Loader[T]) Reload() ([]FieldChange, error) {
+	var next T
+
+	err := ReadFoundConfig(l.path, &next, l.unmarshal, l.finalize)
+	if err != nil {
+		l.failures.Add(1)
+		return nil, err
+	}
+
+	l.successes.Add(1)
+	l.lastGood.Store(time.Now().UnixNano())
+
+	prev := l.current.Swap(&next)
+	if prev == nil {
+		return nil, nil
+	}
+
+	return Diff(*prev, next), nil
+}
+
+// ReloadOnSignal reloads the configuration of loader whenever the process
+// receives one of the given signals, defaulting to SIGHUP, and calls onReload
+// with the result of each reload if onReload is not nil. On platforms other
+// than Unix, such as Windows, there is no default, so that ReloadOnSignal
+// does nothing unless signals are given.
+//
+// The returned stop function unregisters the signal handler and waits for a
+// running reload to finish.
+func ReloadOnSignal[T any](loader *Loader[T], onReload func(error), sig ...os.Signal) (stop func()) {
+	if len(sig) == 0 {
+		sig = reloadSignals
+	}
+
+	if len(sig) == 0 {
+		// signal.Notify would relay all signals.
+		return func() {}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ch:
+				_, err := loader.Reload()
+				if onReload != nil {
+					onReload(err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoaderNonStruct(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	err := os.WriteFile(path, []byte(`{"name":"a"}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLoader(path, JSON[map[string]any](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("got changes %v for unchanged content, want none", changes)
+	}
+
+	err = os.WriteFile(path, []byte(`{"name":"b"}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Path != "" {
+		t.Fatalf("got changes %v, want a single root-level change", changes)
+	}
+
+	if got := changes[0].New.(map[string]any)["name"]; got != "b" {
+		t.Errorf("got new name %v, want b", got)
+	}
+}
+
+func TestLoaderConcurrentCurrent(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "a", "Port": 1}`)
+
+	l, err := NewLoader(path, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+
+	done := make(chan struct{})
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				c := l.Current()
+				if c.Name != "a" && c.Name != "b" {
+					t.Errorf("got name %q, want a or b", c.Name)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range 20 {
+		name := "a"
+		if i%2 == 0 {
+			name = "b"
+		}
+
+		writeFile(t, dir, "config.json", `{"Name": "`+name+`"}`)
+
+		_, err := l.Reload()
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+func TestLoaderFailedReload(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "good"}`)
+
+	l, err := NewLoader(path, JSON[testConfig](), func(c *testConfig) error {
+		if c.Name == "" {
+			return errors.New("name is required")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, content := range []string{`{`, `{"Port": 1}`} {
+		writeFile(t, dir, "config.json", content)
+
+		_, err = l.Reload()
+		if err == nil {
+			t.Errorf("got no error reloading %s", content)
+		}
+
+		if got := l.Current(); got != (testConfig{Name: "good"}) {
+			t.Errorf("got %+v after a failed reload, want the previous configuration", got)
+		}
+	}
+}
+
+func TestNewLoaderError(t *testing.T) {
+	_, err := NewLoader(filepath.Join(t.TempDir(), "missing.json"), JSON[testConfig](), nil)
+	if err == nil {
+		t.Error("got no error for a missing configuration file")
+	}
+}
+
+func TestLoaderReloadChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "a", "Port": 8080}`)
+
+	l, err := NewLoader(path, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("got changes %v for unchanged content, want none", changes)
+	}
+
+	writeFile(t, dir, "config.json", `{"Name": "a", "Port": 9090}`)
+
+	changes, err = l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FieldChange{{Path: "Port", Old: 8080, New: 9090}}
+	if !slices.Equal(changes, want) {
+		t.Errorf("got changes %v, want %v", changes, want)
+	}
+}
+
+func TestLoaderStats(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "a"}`)
+
+	before := time.Now()
+
+	l, err := NewLoader(path, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initial := l.Stats()
+	if initial.Successes != 1 || initial.Failures != 0 || initial.LastGood.Before(before) {
+		t.Errorf("got %+v after the initial load", initial)
+	}
+
+	for _, content := range []string{`{"Name": "b"}`, `{`, `{"Name": "c"}`, `{"Name":`, `[]`} {
+		writeFile(t, dir, "config.json", content)
+		l.Reload()
+	}
+
+	stats := l.Stats()
+	if stats.Successes != 3 || stats.Failures != 3 {
+		t.Errorf("got %d successes and %d failures, want 3 of each", stats.Successes, stats.Failures)
+	}
+
+	if stats.LastGood.Before(initial.LastGood) {
+		t.Errorf("got last good load at %s, want it no earlier than %s", stats.LastGood, initial.LastGood)
+	}
+
+	last := stats.LastGood
+
+	writeFile(t, dir, "config.json", `{`)
+	l.Reload()
+
+	if got := l.Stats().LastGood; !got.Equal(last) {
+		t.Errorf("got last good load at %s after a failure, want %s", got, last)
+	}
+}
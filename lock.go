@@ -0,0 +1,80 @@
+package gonfig
+
+import (
+	"fmt"
+	"os"
+)
+
+// lockConfig acquires a lock on the lock file of the configuration file at
+// path, which is path with a .lock extension appended, creating it if needed.
+// The configuration file itself cannot be locked, since it is replaced
+// atomically when written. Returns a function releasing the lock.
+//
+// The lock file is created with the permissions of the configuration file,
+// or 0644 if it does not exist yet, so that every user able to read the
+// configuration file can lock it as well. If it cannot be created, e.g.
+// because the directory is not writable, an existing lock file is used.
+func lockConfig(path string, exclusive bool) (func(), error) {
+	mode := os.FileMode(0o644)
+
+	info, err := os.Stat(path)
+	if err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.OpenFile(path+".lock", os.O_RDONLY|os.O_CREATE, mode)
+	if err != nil {
+		var oerr error
+
+		f, oerr = os.Open(path + ".lock")
+		if oerr != nil {
+			return nil, fmt.Errorf("unable to open lock file for configuration file %s: %w", path, err)
+		}
+	}
+
+	err = lock(f, exclusive)
+	if err != nil {
+		f.Close()
+
+		return nil, fmt.Errorf("unable to lock configuration file %s: %w", path, err)
+	}
+
+	return func() {
+		unlock(f)
+		f.Close()
+	}, nil
+}
+
+// ReadConfigLocked is like ReadConfig, but holds a shared lock on the lock
+// file of the configuration file while reading it, so that it waits for
+// concurrent writers using WriteConfig to finish and never reads a
+// configuration that is about to be replaced. Reading standard input is not
+// locked.
+//
+// The lock file is the path of the configuration file with a .lock extension
+// appended and is created if it does not exist, which requires write access to
+// the directory. Locking uses flock on Unix and LockFileEx on Windows, and is
+// skipped on other platforms. The locks are advisory, so that they only
+// coordinate processes using them.
+func ReadConfigLocked[T any](path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, error) {
+	var err error
+
+	o := newOptions(opts)
+
+	if path == StdinPath {
+		return stdinName, readConfig(stdinName, os.Stdin, c, unmarshal, finalize, o)
+	}
+
+	path, err = FindConfig(path, searchPaths, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	release, err := lockConfig(path, false)
+	if err != nil {
+		return path, err
+	}
+	defer release()
+
+	return path, readFoundConfig(path, c, unmarshal, finalize, o)
+}
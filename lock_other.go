@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package gonfig
+
+import "os"
+
+// lock is a no-op on platforms without file locking.
+func lock(*os.File, bool) error {
+	return nil
+}
+
+// unlock is a no-op on platforms without file locking.
+func unlock(*os.File) error {
+	return nil
+}
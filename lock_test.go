@@ -0,0 +1,84 @@
+package gonfig
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestReadConfigLocked(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "a"}`)
+
+	var c testConfig
+
+	got, err := ReadConfigLocked(path, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != path || c.Name != "a" {
+		t.Errorf("got path %q and name %q, want %q and a", got, c.Name, path)
+	}
+
+	_, err = os.Stat(path + ".lock")
+	if err != nil {
+		t.Errorf("got error %v for the lock file, want it to be created", err)
+	}
+}
+
+func TestLockConfigMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissions are not supported")
+	}
+
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{}`)
+
+	err := os.Chmod(path, 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := lockConfig(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	info, err := os.Stat(path + ".lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o640 {
+		t.Errorf("got mode %v for the lock file, want the mode of the configuration file 0640", perm)
+	}
+}
+
+func TestLockConfigReadOnlyDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissions are not supported")
+	}
+
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{}`)
+	writeFile(t, dir, "config.json.lock", "")
+
+	err := os.Chmod(dir, 0o555)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(dir, 0o755) })
+
+	_, err = os.Create(filepath.Join(dir, "probe"))
+	if err == nil {
+		t.Skip("directory permissions are not enforced")
+	}
+
+	release, err := lockConfig(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -0,0 +1,79 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows
+
+package gonfig
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadConfigLockedWaitsForWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "old"}`)
+
+	release, err := lockConfig(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		c   testConfig
+		err error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		var r result
+		_, r.err = ReadConfigLocked(path, nil, &r.c, JSON[testConfig](), nil)
+		done <- r
+	}()
+
+	select {
+	case r := <-done:
+		release()
+		t.Fatalf("got %+v and error %v while a writer holds the lock, want the reader to wait", r.c, r.err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	writeFile(t, dir, "config.json", `{"Name": "new"}`)
+	release()
+
+	r := <-done
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+
+	if r.c.Name != "new" {
+		t.Errorf("got name %q, want the content written while holding the lock", r.c.Name)
+	}
+}
+
+func TestWriteConfigWaitsForReader(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "old"}`)
+
+	release, err := lockConfig(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- WriteConfig(path, &testConfig{Name: "new"}, MarshalJSON[testConfig]())
+	}()
+
+	select {
+	case err := <-done:
+		release()
+		t.Fatalf("got write with error %v while a reader holds the lock, want the writer to wait", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+
+	err = <-done
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package gonfig
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lock locks f, exclusively or shared, blocking until the lock is acquired.
+func lock(f *os.File, exclusive bool) error {
+	var flags uint32
+	if exclusive {
+		flags = windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+
+	return windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, &windows.Overlapped{})
+}
+
+// unlock releases the lock on f.
+func unlock(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
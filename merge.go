@@ -0,0 +1,249 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// ReadConfigMerged reads multiple configuration files in order and merges
+// them into the given configuration object, with later files overriding
+// earlier ones. The merged result is validated once.
+//
+// Each file is unmarshaled into a zero value of type T, whose non-zero fields
+// then override the corresponding fields of c. Nested structs are merged field
+// by field, while all other values, including slices and maps, are replaced
+// unless tagged otherwise as described for Merge. Glob patterns are expanded
+// as in FindConfig. Paths that do not exist are skipped. Returns an error
+// satisfying ErrConfigNotFound if none of the paths exist.
+//
+// The files are merged into a copy of c, which only replaces *c if reading,
+// merging, and validation succeed, so that c is never left half-merged.
+func ReadConfigMerged[T any](paths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	found := false
+
+	expanded, err := expandGlobs(osFinder, paths)
+	if err != nil {
+		return err
+	}
+
+	next := *c
+	copyPointers(reflect.ValueOf(&next).Elem())
+
+	for _, path := range expanded {
+		var layer T
+
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read configuration file %s: %w", path, err)
+		}
+
+		err = readConfig(path, f, &layer, unmarshal, nil, nil)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		Merge(&next, &layer)
+		found = true
+	}
+
+	if !found {
+		return &NotFoundError{Paths: paths}
+	}
+
+	err = finalizeConfig(&next, finalize)
+	if err != nil {
+		return err
+	}
+
+	*c = next
+
+	return nil
+}
+
+// ReadConfigLayered reads the defaults file at defaultsPath, e.g. shipped
+// read-only with the application, merges the override file at overridePath
+// on top of it as described for ReadConfigMerged, and validates the result.
+//
+// The defaults file is required, while a missing override file is skipped.
+// The configuration object is only updated if reading, merging, and
+// validation succeed.
+func ReadConfigLayered[T any](defaultsPath, overridePath string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T]) error {
+	var next T
+
+	err := readFoundConfig(defaultsPath, &next, unmarshal, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var layer T
+
+	err = readFoundConfig(overridePath, &layer, unmarshal, nil, nil)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	Merge(&next, &layer)
+
+	err = finalizeConfig(&next, finalize)
+	if err != nil {
+		return err
+	}
+
+	*c = next
+
+	return nil
+}
+
+// LocalOverridePath returns the path of the local override file for the
+// configuration file at path as read with WithLocalOverride, which inserts
+// .local before the extension, e.g. config.local.toml for config.toml, or
+// appends .local if there is no extension.
+func LocalOverridePath(path string) string {
+	ext := filepath.Ext(path)
+
+	return strings.TrimSuffix(path, ext) + ".local" + ext
+}
+
+// mergeLocalOverride merges the local override file for the configuration
+// file at path into next if it exists.
+func mergeLocalOverride[T any](path string, next *T, unmarshal UnmarshalFunc[*T], o *options) error {
+	local := LocalOverridePath(path)
+
+	read := readLocalOverride
+	if o.readLocal != nil {
+		read = o.readLocal
+	}
+
+	content, err := read(local, o)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		return nil
+	}
+
+	content = StripBOM(content)
+
+	var layer T
+
+	err = unmarshal(content, &layer)
+	if err != nil {
+		line, column := position(err, content)
+
+		return &UnmarshalError{Path: local, Content: content, Line: line, Column: column, Err: err}
+	}
+
+	o.debug("merging local override file", "path", local)
+
+	Merge(next, &layer)
+
+	return nil
+}
+
+// readLocalOverride reads the local override file at path, checking its
+// permissions like those of the configuration file. Returns nil content
+// without an error if the file does not exist.
+func readLocalOverride(path string, o *options) ([]byte, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read local override file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("could not stat local override file %s: %w", path, err)
+	}
+
+	err = checkPerms(path, info, o)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := readAll(f, o)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read local override file %s: %w", path, err)
+	}
+
+	return content, nil
+}
+
+// Merge merges src into dst. Non-zero fields of src override the
+// corresponding fields of dst. Nested structs are merged field by field, while
+// all other values, including slices and maps, are replaced.
+//
+// Slice and map fields tagged with `gonfig:"merge=append"` are combined
+// instead: the elements of src are appended to those of dst, and the entries
+// of src are added to those of dst, overriding entries with the same key. The
+// tag `gonfig:"merge=replace"` explicitly selects the default behavior.
+func Merge[T any](dst, src *T) {
+	mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), false)
+}
+
+// mergeValue merges src into dst. If combine is set, slices and maps are
+// combined rather than replaced.
+func mergeValue(dst, src reflect.Value, combine bool) {
+	switch {
+	case isStruct(dst.Type()):
+		for i := range dst.NumField() {
+			sf := dst.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			mergeValue(dst.Field(i), src.Field(i), parseTag(sf)["merge"] == "append")
+		}
+	case dst.Kind() == reflect.Pointer && isStruct(dst.Type().Elem()):
+		if src.IsNil() {
+			return
+		}
+
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		mergeValue(dst.Elem(), src.Elem(), false)
+	case combine && dst.Kind() == reflect.Slice:
+		if src.Len() == 0 {
+			return
+		}
+
+		// Appending to a fresh slice keeps the backing array of dst, which
+		// may be shared, untouched.
+		combined := reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len())
+		combined = reflect.AppendSlice(combined, dst)
+		dst.Set(reflect.AppendSlice(combined, src))
+	case combine && dst.Kind() == reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+
+		// Likewise, the entries are added to a copy of the map of dst.
+		combined := reflect.MakeMapWithSize(dst.Type(), dst.Len()+src.Len())
+
+		for _, m := range []reflect.Value{dst, src} {
+			iter := m.MapRange()
+			for iter.Next() {
+				combined.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+
+		dst.Set(combined)
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
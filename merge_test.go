@@ -0,0 +1,292 @@
+package gonfig
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadConfigMergedAllOrNothing(t *testing.T) {
+	type server struct {
+		Host string
+	}
+
+	type config struct {
+		Name   string
+		Port   int
+		Server *server
+	}
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+
+	err := os.WriteFile(first, []byte(`{"Name":"first","Server":{"Host":"example.com"}}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		finalize FinalizeFunc[*config]
+	}{
+		{"syntax error", `{`, nil},
+		{"validation error", `{"Port":1}`, func(*config) error { return errors.New("invalid") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := os.WriteFile(second, []byte(tt.content), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := config{Name: "initial", Server: &server{Host: "localhost"}}
+
+			err = ReadConfigMerged([]string{first, second}, &c, JSON[config](), tt.finalize)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			if c.Name != "initial" || c.Port != 0 || c.Server.Host != "localhost" {
+				t.Errorf("got %+v with server %+v, want the initial configuration", c, *c.Server)
+			}
+		})
+	}
+}
+
+func TestReadConfigMerged(t *testing.T) {
+	type config struct {
+		Name     string
+		Port     int
+		Hosts    []string
+		Plugins  []string `gonfig:"merge=append"`
+		Database struct {
+			Host string
+			Port int
+		}
+	}
+
+	dir := t.TempDir()
+	system := writeFile(t, dir, "system.json", `{"Name":"system","Port":80,"Hosts":["a"],"Plugins":["x"],"Database":{"Host":"db","Port":5432}}`)
+	user := writeFile(t, dir, "user.json", `{"Port":8080,"Hosts":["b"],"Plugins":["y"],"Database":{"Port":6543}}`)
+
+	var c config
+
+	err := ReadConfigMerged([]string{system, filepath.Join(dir, "missing.json"), user}, &c, JSON[config](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "system" || c.Port != 8080 {
+		t.Errorf("got name %q and port %d, want scalars set by later files to win", c.Name, c.Port)
+	}
+
+	if !slices.Equal(c.Hosts, []string{"b"}) {
+		t.Errorf("got hosts %q, want them replaced", c.Hosts)
+	}
+
+	if !slices.Equal(c.Plugins, []string{"x", "y"}) {
+		t.Errorf("got plugins %q, want them appended", c.Plugins)
+	}
+
+	if c.Database.Host != "db" || c.Database.Port != 6543 {
+		t.Errorf("got database %+v, want nested structs merged field by field", c.Database)
+	}
+}
+
+func TestReadConfigMergedNoneExist(t *testing.T) {
+	dir := t.TempDir()
+
+	var c testConfig
+
+	err := ReadConfigMerged([]string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound", err)
+	}
+}
+
+func TestMergePolicies(t *testing.T) {
+	type config struct {
+		Listen   []string
+		Plugins  []string          `gonfig:"merge=append"`
+		Replaced []string          `gonfig:"merge=replace"`
+		Labels   map[string]string `gonfig:"merge=append"`
+		Env      map[string]string
+	}
+
+	dst := config{
+		Listen:   []string{":80"},
+		Plugins:  []string{"a"},
+		Replaced: []string{"x"},
+		Labels:   map[string]string{"team": "core", "tier": "web"},
+		Env:      map[string]string{"A": "1"},
+	}
+	src := config{
+		Listen:   []string{":8080"},
+		Plugins:  []string{"b"},
+		Replaced: []string{"y"},
+		Labels:   map[string]string{"tier": "api"},
+		Env:      map[string]string{"B": "2"},
+	}
+
+	Merge(&dst, &src)
+
+	if !slices.Equal(dst.Listen, []string{":8080"}) || !slices.Equal(dst.Replaced, []string{"y"}) {
+		t.Errorf("got listen %q and replaced %q, want them replaced", dst.Listen, dst.Replaced)
+	}
+
+	if !slices.Equal(dst.Plugins, []string{"a", "b"}) {
+		t.Errorf("got plugins %q, want them appended", dst.Plugins)
+	}
+
+	if want := map[string]string{"team": "core", "tier": "api"}; !maps.Equal(dst.Labels, want) {
+		t.Errorf("got labels %v, want %v", dst.Labels, want)
+	}
+
+	if want := map[string]string{"B": "2"}; !maps.Equal(dst.Env, want) {
+		t.Errorf("got env %v, want %v", dst.Env, want)
+	}
+}
+
+func TestMergeKeepsUnsetFields(t *testing.T) {
+	type config struct {
+		Plugins []string `gonfig:"merge=append"`
+		Env     map[string]string
+	}
+
+	dst := config{Plugins: []string{"a"}, Env: map[string]string{"A": "1"}}
+
+	Merge(&dst, &config{})
+
+	if !slices.Equal(dst.Plugins, []string{"a"}) || !maps.Equal(dst.Env, map[string]string{"A": "1"}) {
+		t.Errorf("got %+v, want empty values in src to be ignored", dst)
+	}
+}
+
+func TestReadConfigLayered(t *testing.T) {
+	dir := t.TempDir()
+	defaults := writeFile(t, dir, "defaults.json", `{"Name": "app", "Port": 8080}`)
+	override := writeFile(t, dir, "override.json", `{"Port": 9090}`)
+
+	tests := []struct {
+		name     string
+		override string
+		want     testConfig
+	}{
+		{"present override", override, testConfig{Name: "app", Port: 9090}},
+		{"absent override", filepath.Join(dir, "missing.json"), testConfig{Name: "app", Port: 8080}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c testConfig
+
+			err := ReadConfigLayered(defaults, tt.override, &c, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c != tt.want {
+				t.Errorf("got %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigLayeredMissingDefaults(t *testing.T) {
+	dir := t.TempDir()
+	override := writeFile(t, dir, "override.json", `{"Port": 9090}`)
+
+	c := testConfig{Name: "initial"}
+
+	err := ReadConfigLayered(filepath.Join(dir, "missing.json"), override, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v, want it to match os.ErrNotExist", err)
+	}
+
+	if c != (testConfig{Name: "initial"}) {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+}
+
+func TestLocalOverridePath(t *testing.T) {
+	for path, want := range map[string]string{
+		"config.toml":          "config.local.toml",
+		"/etc/app/config.json": "/etc/app/config.local.json",
+		"config":               "config.local",
+	} {
+		if got := LocalOverridePath(path); got != want {
+			t.Errorf("got %q for %q, want %q", got, path, want)
+		}
+	}
+}
+
+func TestWithLocalOverride(t *testing.T) {
+	tests := []struct {
+		name  string
+		local string
+		want  testConfig
+	}{
+		{"present", `{"Port": 9090}`, testConfig{Name: "app", Port: 9090}},
+		{"absent", "", testConfig{Name: "app", Port: 8080}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := writeFile(t, dir, "config.json", `{"Name": "app", "Port": 8080}`)
+
+			if tt.local != "" {
+				writeFile(t, dir, "config.local.json", tt.local)
+			}
+
+			var c testConfig
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, WithLocalOverride())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c != tt.want {
+				t.Errorf("got %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithLocalOverrideErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "app", "Port": 8080}`)
+	local := writeFile(t, dir, "config.local.json", `{`)
+
+	c := testConfig{Name: "initial"}
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, WithLocalOverride())
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) || unmarshalErr.Path != local {
+		t.Errorf("got error %v, want an *UnmarshalError for %s", err, local)
+	}
+
+	writeFile(t, dir, "config.local.json", `{"Port": -1}`)
+
+	_, err = ReadConfig(path, nil, &c, JSON[testConfig](), func(c *testConfig) error {
+		if c.Port < 0 {
+			return errors.New("invalid port")
+		}
+
+		return nil
+	}, WithLocalOverride())
+	if err == nil {
+		t.Error("got no error, want the merged result to be validated")
+	}
+
+	if c != (testConfig{Name: "initial"}) {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+}
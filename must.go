@@ -0,0 +1,17 @@
+package gonfig
+
+// MustReadConfig is like ReadConfig, but returns the configuration object by
+// value and panics if it cannot be located, read, unmarshaled, or validated.
+//
+// It is intended for program initialization, e.g. in main, where failing to
+// load the configuration is fatal anyway.
+func MustReadConfig[T any](path string, searchPaths []string, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, T) {
+	var c T
+
+	path, err := ReadConfig(path, searchPaths, &c, unmarshal, finalize, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return path, c
+}
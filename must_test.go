@@ -0,0 +1,33 @@
+package gonfig
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestMustReadConfig(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "app"}`)
+
+	got, c := MustReadConfig(path, nil, JSON[testConfig](), nil)
+	if got != path {
+		t.Errorf("got path %q, want %q", got, path)
+	}
+
+	if c.Name != "app" {
+		t.Errorf("got name %q, want app", c.Name)
+	}
+}
+
+func TestMustReadConfigPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got panic %v, want ErrConfigNotFound", r)
+		}
+	}()
+
+	MustReadConfig(filepath.Join(t.TempDir(), "missing.json"), nil, JSON[testConfig](), nil)
+}
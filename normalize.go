@@ -0,0 +1,77 @@
+package gonfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// WithNormalize returns a FinalizeFunc that calls normalize before finalize.
+// Use it to normalize values after unmarshaling and before validation, e.g.
+// to lower-case host names or to make paths absolute using AbsolutizePaths.
+func WithNormalize[T any](normalize func(*T) error, finalize FinalizeFunc[*T]) FinalizeFunc[*T] {
+	return func(c *T) error {
+		err := normalize(c)
+		if err != nil {
+			return err
+		}
+
+		return finalizeConfig(c, finalize)
+	}
+}
+
+// AbsolutizePaths returns a normalize function for WithNormalize that makes
+// the relative paths held by fields tagged with `gonfig:"path"` absolute by
+// resolving them against baseDir, typically the directory of the configuration
+// file. The tag applies to string fields and slices of strings. Empty paths
+// are left as is.
+func AbsolutizePaths[T any](baseDir string) func(*T) error {
+	return func(c *T) error {
+		baseDir, err := filepath.Abs(baseDir)
+		if err != nil {
+			return err
+		}
+
+		return walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			if !parseTag(path[len(path)-1]).has("path") {
+				return nil
+			}
+
+			updateStrings(v, func(p string) string {
+				if p == "" || filepath.IsAbs(p) {
+					return p
+				}
+
+				return filepath.Join(baseDir, p)
+			})
+
+			return nil
+		})
+	}
+}
+
+// ResolveSecretFiles is a normalize function for WithNormalize that replaces
+// the value of every string field tagged with `gonfig:"secretfile"` with the
+// content of the file it names, with leading and trailing white space
+// removed. This follows the convention of Docker and Kubernetes secrets,
+// which are mounted as files, and keeps secrets out of the configuration
+// file. Empty values are left as is. Returns an error naming the field if a
+// secret file cannot be read.
+func ResolveSecretFiles[T any](c *T) error {
+	return walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+		if v.Kind() != reflect.String || v.String() == "" || !parseTag(path[len(path)-1]).has("secretfile") {
+			return nil
+		}
+
+		content, err := os.ReadFile(v.String())
+		if err != nil {
+			return fmt.Errorf("unable to read secret file for %s: %w", fieldPath(path), err)
+		}
+
+		v.SetString(strings.TrimSpace(string(content)))
+
+		return nil
+	})
+}
@@ -0,0 +1,107 @@
+package gonfig
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAbsolutizePaths(t *testing.T) {
+	type config struct {
+		Host    string
+		Cert    string   `gonfig:"path"`
+		Key     string   `gonfig:"path"`
+		Plugins []string `gonfig:"path"`
+		Empty   string   `gonfig:"path"`
+		Other   string
+	}
+
+	dir := t.TempDir()
+	abs := filepath.Join(dir, "abs.pem")
+	path := writeFile(t, dir, "config.json", `{
+		"Host": " Example.COM ",
+		"Cert": "certs/cert.pem",
+		"Key": "`+filepath.ToSlash(abs)+`",
+		"Plugins": ["plugins/a.so"],
+		"Other": "relative"
+	}`)
+
+	normalize := func(c *config) error {
+		c.Host = strings.ToLower(strings.TrimSpace(c.Host))
+
+		return AbsolutizePaths[config](filepath.Dir(path))(c)
+	}
+
+	var c, validated config
+
+	_, err := ReadConfig(path, nil, &c, JSON[config](), WithNormalize(normalize, func(c *config) error {
+		validated = *c
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := filepath.Join(dir, "certs", "cert.pem"); c.Cert != want {
+		t.Errorf("got cert %q, want %q", c.Cert, want)
+	}
+
+	if filepath.Clean(c.Key) != abs {
+		t.Errorf("got key %q, want the absolute path %q kept", c.Key, abs)
+	}
+
+	if want := []string{filepath.Join(dir, "plugins", "a.so")}; !slices.Equal(c.Plugins, want) {
+		t.Errorf("got plugins %q, want %q", c.Plugins, want)
+	}
+
+	if c.Empty != "" || c.Other != "relative" {
+		t.Errorf("got empty %q and other %q, want them untouched", c.Empty, c.Other)
+	}
+
+	if c.Host != "example.com" || validated.Host != "example.com" {
+		t.Errorf("got host %q, validated %q, want normalization before validation", c.Host, validated.Host)
+	}
+}
+
+func TestResolveSecretFiles(t *testing.T) {
+	type config struct {
+		Password string `gonfig:"secretfile"`
+		Token    string `gonfig:"secretfile"`
+		Name     string
+	}
+
+	dir := t.TempDir()
+	secret := writeFile(t, dir, "password", "hunter2\n")
+	path := writeFile(t, dir, "config.json", `{"Password": "`+filepath.ToSlash(secret)+`", "Name": "app"}`)
+
+	var c config
+
+	_, err := ReadConfig(path, nil, &c, JSON[config](), WithNormalize(ResolveSecretFiles[config], nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (config{Password: "hunter2", Name: "app"}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestResolveSecretFilesMissing(t *testing.T) {
+	type config struct {
+		Password string `gonfig:"secretfile"`
+	}
+
+	c := config{Password: filepath.Join(t.TempDir(), "missing")}
+
+	err := ResolveSecretFiles(&c)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("got error %v, want it to match fs.ErrNotExist", err)
+	}
+
+	if !strings.Contains(err.Error(), "Password") {
+		t.Errorf("error %q does not name the field", err)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package gonfig
+
+// openFlags are the flags added when opening a configuration file whose type
+// has not been checked yet.
+const openFlags = 0
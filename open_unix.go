@@ -0,0 +1,9 @@
+//go:build unix
+
+package gonfig
+
+import "syscall"
+
+// openFlags are the flags added when opening a configuration file whose type
+// has not been checked yet.
+const openFlags = syscall.O_NONBLOCK
@@ -0,0 +1,92 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ReadConfigOrDefault is like ReadConfig, but treats a configuration file
+// that cannot be located in the fallback paths as optional. In that case,
+// finalize runs on c as is, e.g. to apply defaults and validate them, and
+// found is false.
+//
+// This separates a missing configuration file from a malformed one. A
+// primary path that was given explicitly must still exist.
+func ReadConfigOrDefault[T any](path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (found bool, resolved string, err error) {
+	resolved, err = ReadConfig(path, searchPaths, c, unmarshal, finalize, opts...)
+	if path == "" && errors.Is(err, ErrConfigNotFound) {
+		return false, "", finalizeConfig(c, finalize)
+	}
+
+	return resolved != "", resolved, err
+}
+
+// ReadConfigWithEmbeddedDefault is like ReadConfigOrDefault, but starts from
+// the default configuration in defaultContent, e.g. embedded into the binary
+// using go:embed, and merges the configuration file on top of it as described
+// for Merge if one is found. This guarantees a complete configuration even if
+// no configuration file exists. The merged result is validated once.
+//
+// Returns the resolved path, or an empty path if only the defaults are used.
+// The configuration object is only updated if reading, merging, and
+// validation succeed.
+func ReadConfigWithEmbeddedDefault[T any](defaultContent []byte, path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, error) {
+	var next T
+
+	err := processConfig("", defaultContent, &next, unmarshal, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid default configuration: %w", err)
+	}
+
+	var layer T
+
+	resolved, err := ReadConfig(path, searchPaths, &layer, unmarshal, nil, opts...)
+	if err != nil && (path != "" || !errors.Is(err, ErrConfigNotFound)) {
+		return resolved, err
+	}
+
+	Merge(&next, &layer)
+
+	err = finalizeConfig(&next, finalize)
+	if err != nil {
+		return resolved, err
+	}
+
+	*c = next
+
+	return resolved, nil
+}
+
+// ReadConfigOverlay is like ReadConfig, but starts from the fully populated
+// defaults, e.g. computed at runtime, instead of default struct tags, and
+// merges the configuration file on top of them as described for Merge. Fields
+// not set by the configuration file thus keep their default values. Note that
+// a field cannot be reset to its zero value this way, since zero values in
+// the configuration file are indistinguishable from unset fields. The merged
+// result is validated once.
+//
+// Returns the configuration object and the resolved path. defaults is left
+// untouched.
+func ReadConfigOverlay[T any](defaults T, path string, searchPaths []string, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (T, string, error) {
+	var layer T
+
+	resolved, err := ReadConfig(path, searchPaths, &layer, unmarshal, nil, opts...)
+	if err != nil {
+		var zero T
+		return zero, resolved, err
+	}
+
+	next := defaults
+	copyPointers(reflect.ValueOf(&next).Elem())
+
+	Merge(&next, &layer)
+
+	err = finalizeConfig(&next, finalize)
+	if err != nil {
+		var zero T
+		return zero, resolved, err
+	}
+
+	return next, resolved, nil
+}
@@ -0,0 +1,237 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadConfigOverlay(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Port":8080}`)
+
+	defaults := testConfig{Name: "default", Port: 80}
+
+	var validated testConfig
+
+	c, got, err := ReadConfigOverlay(defaults, "", []string{filepath.Join(dir, "missing.json"), path}, JSON[testConfig](), func(c *testConfig) error {
+		validated = *c
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := testConfig{Name: "default", Port: 8080}
+	if c != want || validated != want {
+		t.Errorf("got %+v and validated %+v, want %+v", c, validated, want)
+	}
+
+	if got != path {
+		t.Errorf("got path %q, want %q", got, path)
+	}
+
+	errInvalid := errors.New("invalid")
+
+	c, _, err = ReadConfigOverlay(defaults, path, nil, JSON[testConfig](), func(*testConfig) error { return errInvalid })
+	if !errors.Is(err, errInvalid) || c != (testConfig{}) {
+		t.Errorf("got %+v and error %v, want the zero value and %v", c, err, errInvalid)
+	}
+
+	c, _, err = ReadConfigOverlay(defaults, filepath.Join(dir, "missing.json"), nil, JSON[testConfig](), nil)
+	if !errors.Is(err, ErrConfigNotFound) || c != (testConfig{}) {
+		t.Errorf("got %+v and error %v, want the zero value and ErrConfigNotFound", c, err)
+	}
+}
+
+func TestReadConfigOverlayLeavesDefaultsUntouched(t *testing.T) {
+	type database struct {
+		Host string
+		Port int
+	}
+
+	type config struct {
+		Name     string
+		Database *database
+		Map      map[string]int `gonfig:"merge=append"`
+		List     []string       `gonfig:"merge=append"`
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	err := os.WriteFile(path, []byte(`{"Database":{"Port":1},"Map":{"b":2},"List":["c"]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := make([]string, 2, 3)
+	list[0], list[1] = "a", "b"
+
+	defaults := config{
+		Name:     "default",
+		Database: &database{Host: "localhost", Port: 5432},
+		Map:      map[string]int{"a": 1},
+		List:     list,
+	}
+
+	c, _, err := ReadConfigOverlay(defaults, path, nil, JSON[config](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config{
+		Name:     "default",
+		Database: &database{Host: "localhost", Port: 1},
+		Map:      map[string]int{"a": 1, "b": 2},
+		List:     []string{"a", "b", "c"},
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+
+	if defaults.Database.Port != 5432 {
+		t.Errorf("defaults.Database.Port changed to %d", defaults.Database.Port)
+	}
+
+	if len(defaults.Map) != 1 {
+		t.Errorf("defaults.Map changed to %v", defaults.Map)
+	}
+
+	if got := list[:3]; got[2] != "" {
+		t.Errorf("backing array of defaults.List changed to %v", got)
+	}
+}
+
+func TestReadConfigOrDefault(t *testing.T) {
+	dir := t.TempDir()
+	present := writeFile(t, dir, "config.json", `{"Name": "file"}`)
+	missing := filepath.Join(dir, "missing.json")
+
+	finalize := WithDefaults(func(c *defaultsConfig) error {
+		if c.Port == 0 {
+			return errors.New("port is required")
+		}
+
+		return nil
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var c defaultsConfig
+
+		found, path, err := ReadConfigOrDefault("", []string{missing}, &c, JSON[defaultsConfig](), finalize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if found || path != "" {
+			t.Errorf("got found %t and path %q, want no file", found, path)
+		}
+
+		if c.Name != "app" || c.Port != 8080 {
+			t.Errorf("got %+v, want the defaults", c)
+		}
+	})
+
+	t.Run("present", func(t *testing.T) {
+		var c defaultsConfig
+
+		found, path, err := ReadConfigOrDefault("", []string{missing, present}, &c, JSON[defaultsConfig](), finalize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !found || path != present {
+			t.Errorf("got found %t and path %q, want %q", found, path, present)
+		}
+
+		if c.Name != "file" || c.Port != 8080 {
+			t.Errorf("got %+v, want the file merged with the defaults", c)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		malformed := writeFile(t, dir, "malformed.json", `{`)
+
+		var c defaultsConfig
+
+		found, _, err := ReadConfigOrDefault("", []string{malformed}, &c, JSON[defaultsConfig](), finalize)
+		if err == nil || !found {
+			t.Errorf("got found %t and error %v, want a malformed file to fail", found, err)
+		}
+	})
+
+	t.Run("missing primary", func(t *testing.T) {
+		var c defaultsConfig
+
+		_, _, err := ReadConfigOrDefault(missing, nil, &c, JSON[defaultsConfig](), finalize)
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want ErrConfigNotFound for an explicit path", err)
+		}
+	})
+}
+
+func TestReadConfigWithEmbeddedDefault(t *testing.T) {
+	defaults := []byte(`{"Name": "app", "Port": 8080}`)
+
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Port": 9090}`)
+	missing := filepath.Join(dir, "missing.json")
+
+	tests := []struct {
+		name        string
+		searchPaths []string
+		wantPath    string
+		want        testConfig
+	}{
+		{"no file", []string{missing}, "", testConfig{Name: "app", Port: 8080}},
+		{"file present", []string{missing, path}, path, testConfig{Name: "app", Port: 9090}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c testConfig
+
+			got, err := ReadConfigWithEmbeddedDefault(defaults, "", tt.searchPaths, &c, JSON[testConfig](), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.wantPath || c != tt.want {
+				t.Errorf("got %+v from %q, want %+v from %q", c, got, tt.want, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestReadConfigWithEmbeddedDefaultErrors(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.json")
+	malformed := writeFile(t, dir, "malformed.json", `{`)
+
+	tests := []struct {
+		name     string
+		defaults string
+		path     string
+	}{
+		{"invalid defaults", `{`, ""},
+		{"missing primary path", `{}`, missing},
+		{"malformed file", `{}`, malformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig{Name: "initial"}
+
+			_, err := ReadConfigWithEmbeddedDefault([]byte(tt.defaults), tt.path, nil, &c, JSON[testConfig](), nil)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			if c != (testConfig{Name: "initial"}) {
+				t.Errorf("got %+v, want the initial configuration", c)
+			}
+		})
+	}
+}
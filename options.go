@@ -0,0 +1,321 @@
+package gonfig
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Option configures how configuration files are located and read.
+type Option func(*options)
+
+// options holds the settings configured by Options. A nil *options holds the
+// defaults.
+type options struct {
+	logger  *slog.Logger
+	maxSize int64
+	client  *http.Client
+	policy  SelectionPolicy
+
+	securePerms   bool
+	noWriteOthers bool
+	requireOwner  bool
+	allowEmpty    bool
+	allowExec     bool
+	requireUTF8   bool
+	localOverride bool
+	// localFile marks content as read from the local file at its path, to
+	// which WithLocalOverride applies. It is set by forFile.
+	localFile bool
+
+	baseDir string
+	// lookup, if not nil, expands variables in paths.
+	lookup func(string) (string, bool)
+	// readLocal, if not nil, replaces readLocalOverride.
+	readLocal func(string, *options) ([]byte, error)
+	// onInvalid, if not nil, receives validation errors instead of failing.
+	onInvalid func(error)
+	// decrypt, if not nil, decrypts content for which encrypted reports
+	// true.
+	decrypt   func([]byte) ([]byte, error)
+	encrypted func([]byte) bool
+}
+
+// DefaultMaxSize is the default maximum size of configuration content in
+// bytes.
+const DefaultMaxSize = 10 << 20
+
+// newOptions applies opts to the default options.
+func newOptions(opts []Option) *options {
+	o := &options{
+		maxSize: DefaultMaxSize,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithLogger makes the read pipeline log which paths are tried, which one is
+// selected, and how much content was read in how much time to l at debug
+// level. A nil logger disables logging, which is the default.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithMaxSize limits the size of configuration content to n bytes, so that
+// pointing the configuration path at a huge file or a device like /dev/zero
+// fails fast instead of exhausting memory. The limit also applies to the
+// decompressed size of gzip-compressed content. A limit of 0 or less
+// disables the check. Defaults to DefaultMaxSize.
+func WithMaxSize(n int64) Option {
+	return func(o *options) {
+		o.maxSize = n
+	}
+}
+
+// WithHTTPClient sets the client used to fetch configuration over HTTP.
+// Defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// httpClient returns the client used to fetch configuration over HTTP.
+func (o *options) httpClient() *http.Client {
+	if o == nil || o.client == nil {
+		return http.DefaultClient
+	}
+
+	return o.client
+}
+
+// WithSelectionPolicy sets the policy for selecting among multiple existing
+// fallback paths. Defaults to FirstMatch.
+func WithSelectionPolicy(policy SelectionPolicy) Option {
+	return func(o *options) {
+		o.policy = policy
+	}
+}
+
+// selectionPolicy returns the policy for selecting among multiple existing
+// fallback paths.
+func (o *options) selectionPolicy() SelectionPolicy {
+	if o == nil {
+		return FirstMatch
+	}
+
+	return o.policy
+}
+
+// RequireSecurePerms makes reading a configuration file fail if the file is
+// accessible by users other than its owner and group, similar to how SSH
+// treats private keys. Use it for configuration files containing secrets.
+// Modes like 0600 and 0640 pass, while 0644 fails. The check is skipped on
+// platforms without Unix permissions, such as Windows.
+func RequireSecurePerms() Option {
+	return func(o *options) {
+		o.securePerms = true
+	}
+}
+
+// RequireNonWritableByOthers makes reading a configuration file fail if the
+// file is writable by its group or by others, so that nobody but its owner
+// can tamper with it. Modes like 0644 and 0640 pass, while 0664 and 0666
+// fail. The check is skipped on platforms without Unix permissions, such as
+// Windows.
+func RequireNonWritableByOthers() Option {
+	return func(o *options) {
+		o.noWriteOthers = true
+	}
+}
+
+// RequireOwner makes reading a configuration file fail if the file is not
+// owned by the user running the process, so that a daemon does not pick up a
+// configuration file planted or modifiable by another user. The check is
+// skipped on platforms without Unix permissions, such as Windows.
+func RequireOwner() Option {
+	return func(o *options) {
+		o.requireOwner = true
+	}
+}
+
+// AllowEmpty makes empty configuration content, or content consisting only of
+// whitespace, valid regardless of the format. Instead of unmarshaling such
+// content, which fails for some decoders like encoding/json, the default
+// values from the default struct tags are applied to a zero configuration
+// object as in ApplyDefaults, which is then validated.
+func AllowEmpty() Option {
+	return func(o *options) {
+		o.allowEmpty = true
+	}
+}
+
+// RequireUTF8 makes reading a configuration fail with a clear error
+// reporting the byte offset of the first invalid sequence if the content is
+// not valid UTF-8, e.g. because a binary or mis-encoded file was picked up,
+// rather than with a cryptic error of the decoder. The check applies to the
+// content after decompression, removal of the byte order mark, and
+// decryption.
+func RequireUTF8() Option {
+	return func(o *options) {
+		o.requireUTF8 = true
+	}
+}
+
+// AllowExec enables command substitution for string fields tagged with
+// `gonfig:"exec"`: after unmarshaling, the value of each such field is run as
+// a shell command and replaced with its standard output, with trailing
+// newlines removed, e.g. to fetch a secret using "vault read ...". Reading
+// fails if a command cannot be run or exits with a non-zero status.
+//
+// Since this runs arbitrary commands from the configuration file, it is
+// disabled by default and must only be enabled for trusted files.
+func AllowExec() Option {
+	return func(o *options) {
+		o.allowExec = true
+	}
+}
+
+// WithLocalOverride makes reading a configuration file also read the local
+// override file next to it, as returned by LocalOverridePath, e.g.
+// config.local.toml next to config.toml, and merge it on top as described for
+// Merge before validating the result. This supports keeping local changes in
+// a file ignored by version control. A missing local override file is
+// skipped, while the permission checks apply to it as to the configuration
+// file.
+func WithLocalOverride() Option {
+	return func(o *options) {
+		o.localOverride = true
+	}
+}
+
+// WithBaseDir makes relative primary and fallback paths resolve against dir
+// instead of the current working directory, e.g. against the directory of the
+// executable as returned by ExecutableDir. The resolved path is returned.
+func WithBaseDir(dir string) Option {
+	return func(o *options) {
+		o.baseDir = dir
+	}
+}
+
+// WithPathTemplates expands $VAR and ${VAR} in the primary and fallback paths
+// before they are used, e.g. to support
+// CONFIG_PATH=/etc/app/${REGION}/app.toml. Variables are looked up using
+// lookup, defaulting to os.LookupEnv if nil. Unlike os.ExpandEnv, an
+// undefined variable is reported as an error instead of silently producing a
+// wrong path. See ExpandPathTemplate.
+func WithPathTemplates(lookup func(string) (string, bool)) Option {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	return func(o *options) {
+		o.lookup = lookup
+	}
+}
+
+// resolve expands the variables in p if enabled and resolves the relative
+// path against the base directory, if any. Empty paths are left as is.
+func (o *options) resolve(p string) (string, error) {
+	if o == nil || p == "" {
+		return p, nil
+	}
+
+	if o.lookup != nil {
+		var err error
+
+		p, err = ExpandPathTemplate(p, o.lookup)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if o.baseDir == "" || filepath.IsAbs(p) {
+		return p, nil
+	}
+
+	return filepath.Join(o.baseDir, p), nil
+}
+
+// WarnOnInvalid turns validation failures into warnings: instead of failing,
+// the error returned by the FinalizeFunc is passed to warn, and the
+// configuration object is populated all the same, leaving it to the caller
+// whether to proceed. This suits interactive tools rather than servers.
+// Errors joined by CombineValidators can be split again using the Unwrap
+// method returning []error. Errors locating, reading, or unmarshaling the
+// configuration file still fail.
+func WarnOnInvalid(warn func(error)) Option {
+	return func(o *options) {
+		o.onInvalid = warn
+	}
+}
+
+// WithDecrypt makes reading a configuration decrypt its content using decrypt
+// before unmarshaling it, e.g. by running "sops -d" or using an age identity,
+// so that encrypted configuration files can be kept in version control. The
+// content is only passed to decrypt if detect reports that it is encrypted,
+// so that plain configuration files keep working. detect defaults to
+// IsEncrypted if nil.
+func WithDecrypt(decrypt func([]byte) ([]byte, error), detect func([]byte) bool) Option {
+	if detect == nil {
+		detect = IsEncrypted
+	}
+
+	return func(o *options) {
+		o.decrypt = decrypt
+		o.encrypted = detect
+	}
+}
+
+// invalid passes the validation error err to the warning callback and
+// returns nil if warnings are enabled, and returns err otherwise.
+func (o *options) invalid(err error) error {
+	if err == nil || o == nil || o.onInvalid == nil {
+		return err
+	}
+
+	o.onInvalid(err)
+
+	return nil
+}
+
+// forFile returns a copy of o for processing content read from the local
+// file at its path, so that WithLocalOverride applies to it. Content fetched
+// from elsewhere, e.g. over HTTP or from an archive, has no local override
+// file next to it.
+func (o *options) forFile() *options {
+	if o == nil || !o.localOverride {
+		return o
+	}
+
+	f := *o
+	f.localFile = true
+
+	return &f
+}
+
+// limit returns the maximum size of configuration content, or 0 if there is
+// none.
+func (o *options) limit() int64 {
+	if o == nil {
+		return DefaultMaxSize
+	}
+
+	return max(o.maxSize, 0)
+}
+
+// debug logs msg at debug level if logging is enabled.
+func (o *options) debug(msg string, args ...any) {
+	if o == nil || o.logger == nil {
+		return
+	}
+
+	o.logger.Debug(msg, args...)
+}
@@ -0,0 +1,342 @@
+package gonfig
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.json")
+	path := writeFile(t, dir, "config.json", `{"Name": "app"}`)
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	var c testConfig
+
+	_, err := ReadConfig("", []string{missing, path}, &c, JSON[testConfig](), nil, WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+
+	for _, want := range []string{
+		`msg="trying configuration file" path=` + missing,
+		`msg="trying configuration file" path=` + path,
+		`msg="selected configuration file" path=` + path,
+		`msg="read configuration" path=` + path + ` bytes=15`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWithLoggerNil(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{}`)
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithMaxSize(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"under", `{"Name":"abc"}`, false},
+		{"at", `{"Name":"abcd"}`, false},
+		{"over", `{"Name":"abcde"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.name+".json", tt.content)
+
+			var c testConfig
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, WithMaxSize(15))
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "exceeds the maximum size of 15 bytes") {
+					t.Errorf("got error %v, want a size error", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWithMaxSizeGzip(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json.gz", gzipContent(t, `{"Name":"`+strings.Repeat("a", 100)+`"}`))
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, WithMaxSize(64))
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum size") {
+		t.Errorf("got error %v, want the decompressed size to be limited", err)
+	}
+}
+
+// testAllowEmpty checks that empty and whitespace-only files are read as an
+// empty configuration with defaults applied by each of the unmarshalers.
+func testAllowEmpty(t *testing.T, unmarshalers map[string]UnmarshalFunc[*defaultsConfig]) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, unmarshal := range unmarshalers {
+		for _, content := range []string{"", " \n\t\n"} {
+			path := writeFile(t, dir, "config", content)
+
+			var c defaultsConfig
+
+			_, err := ReadConfig(path, nil, &c, unmarshal, nil, AllowEmpty())
+			if err != nil {
+				t.Errorf("%s: got error %v for content %q", name, err, content)
+				continue
+			}
+
+			if c.Name != "app" || c.Port != 8080 {
+				t.Errorf("%s: got %+v, want the defaults", name, c)
+			}
+		}
+	}
+}
+
+func TestAllowEmpty(t *testing.T) {
+	testAllowEmpty(t, map[string]UnmarshalFunc[*defaultsConfig]{
+		"JSON":       JSON[defaultsConfig](),
+		"JSONStrict": JSONStrict[defaultsConfig](),
+		"JSONC":      JSONC[defaultsConfig](),
+	})
+
+	path := writeFile(t, t.TempDir(), "config.json", "")
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+	if err == nil {
+		t.Error("got no error for an empty JSON file without AllowEmpty")
+	}
+}
+
+func TestRequireUTF8(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{"valid", `{"Name":"héllo"}`, "héllo", ""},
+		{"BOM", "\uFEFF{\"Name\":\"app\"}", "app", ""},
+		{"invalid", "{\"Name\":\"h\xffi\"}", "", "invalid sequence at byte offset 10"},
+		{"BOM and invalid", "\uFEFF{\"Name\":\"\xc3\"}", "", "invalid sequence at byte offset 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, "config.json", tt.content)
+
+			var c testConfig
+
+			_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, RequireUTF8())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), "configuration file "+path+" is not valid UTF-8: "+tt.wantErr) {
+					t.Errorf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Name != tt.want {
+				t.Errorf("got name %q, want %q", c.Name, tt.want)
+			}
+		})
+	}
+
+	path := writeFile(t, dir, "config.json", "{\"Name\":\"h\xffi\"}")
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Errorf("got error %v without RequireUTF8, want none", err)
+	}
+}
+
+func TestWithBaseDir(t *testing.T) {
+	base := t.TempDir()
+	want := writeFile(t, base, "config.json", `{"Name": "base"}`)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	work := t.TempDir()
+	writeFile(t, work, "config.json", `{"Name": "cwd"}`)
+
+	err = os.Chdir(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantPath string
+		wantName string
+	}{
+		{"current working directory", nil, "config.json", "cwd"},
+		{"custom base", []Option{WithBaseDir(base)}, want, "base"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c testConfig
+
+			path, err := ReadConfig("", []string{"config.json"}, &c, JSON[testConfig](), nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if path != tt.wantPath || c.Name != tt.wantName {
+				t.Errorf("got path %q and name %q, want %q and %q", path, c.Name, tt.wantPath, tt.wantName)
+			}
+		})
+	}
+
+	abs := writeFile(t, t.TempDir(), "abs.json", `{"Name": "abs"}`)
+
+	path, err := FindConfig(abs, nil, WithBaseDir(base))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path != abs {
+		t.Errorf("got path %q, want the absolute path %q kept", path, abs)
+	}
+}
+
+func TestWithPathTemplates(t *testing.T) {
+	dir := t.TempDir()
+
+	region := filepath.Join(dir, "eu")
+
+	err := os.Mkdir(region, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := writeFile(t, region, "config.json", `{"Name": "eu"}`)
+
+	t.Setenv("GONFIG_TEST_DIR", dir)
+	t.Setenv("GONFIG_TEST_REGION", "eu")
+
+	template := filepath.Join("${GONFIG_TEST_DIR}", "${GONFIG_TEST_REGION}", "config.json")
+
+	for _, paths := range [][]string{{template}, {"", template}} {
+		var c testConfig
+
+		got, err := ReadConfig(paths[0], paths[1:], &c, JSON[testConfig](), nil, WithPathTemplates(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want || c.Name != "eu" {
+			t.Errorf("got path %q and name %q, want %q and eu", got, c.Name, want)
+		}
+	}
+
+	undefined := filepath.Join(dir, "${GONFIG_TEST_UNDEFINED}", "config.json")
+
+	_, err = FindConfig(undefined, nil, WithPathTemplates(nil))
+	if err == nil || !strings.Contains(err.Error(), "undefined variable GONFIG_TEST_UNDEFINED") {
+		t.Errorf("got error %v, want an undefined variable error", err)
+	}
+
+	_, err = FindConfig("", []string{undefined}, WithPathTemplates(nil))
+	if err == nil || !strings.Contains(err.Error(), "undefined variable GONFIG_TEST_UNDEFINED") {
+		t.Errorf("got error %v for a search path, want an undefined variable error", err)
+	}
+}
+
+func TestWarnOnInvalid(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "app"}`)
+
+	errPort, errName := errors.New("port is not set"), errors.New("name is reserved")
+
+	validate := CombineValidators(
+		func(c *testConfig) error {
+			if c.Port == 0 {
+				return errPort
+			}
+
+			return nil
+		},
+		func(c *testConfig) error { return nil },
+		func(c *testConfig) error { return errName },
+	)
+
+	var warnings []error
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), validate, WarnOnInvalid(func(err error) {
+		warnings = append(warnings, err)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" {
+		t.Errorf("got name %q, want the configuration populated despite the warning", c.Name)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1", len(warnings))
+	}
+
+	joined, ok := warnings[0].(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 || !errors.Is(warnings[0], errPort) || !errors.Is(warnings[0], errName) {
+		t.Errorf("got warning %v, want both validation errors", warnings[0])
+	}
+
+	malformed := writeFile(t, dir, "malformed.json", `{`)
+
+	_, err = ReadConfig(malformed, nil, &c, JSON[testConfig](), validate, WarnOnInvalid(func(err error) {
+		t.Errorf("got warning %v for malformed content", err)
+	}))
+	if err == nil {
+		t.Error("got no error for malformed content")
+	}
+}
@@ -0,0 +1,155 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ApplyOverrides sets fields of the configuration object from a flat map of
+// dotted keys to values, e.g. {"database.port": 5433}, as passed by a test
+// harness or an admin API.
+//
+// Key segments are matched case-insensitively against the json, toml, and
+// yaml struct tags and the names of the fields, numeric segments index into
+// slices and arrays, e.g. servers.0.port, and the last segment may name an
+// entry of a map with string keys. Nil pointers along the way are allocated.
+// Values assignable to a field are set as is, and numbers are converted to
+// other numeric types if that is lossless. Strings are parsed for fields
+// holding booleans, integers, floats, time.Duration, types implementing
+// encoding.TextUnmarshaler, or pointers to any of these, and split at commas
+// for slices of these.
+//
+// Keys are applied in lexical order. If keys are unknown or values cannot be
+// set, the errors are joined, while all other overrides are still applied,
+// so that callers may treat the errors as warnings.
+func ApplyOverrides[T any](c *T, overrides map[string]any) error {
+	var errs []error
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		err := applyOverride(reflect.ValueOf(c).Elem(), strings.Split(key, "."), overrides[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to apply override %s: %w", key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyOverride sets the value located at the key segments within v.
+func applyOverride(v reflect.Value, segments []string, value any) error {
+	for i, segment := range segments {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		switch {
+		case isStruct(v.Type()):
+			field, ok := lookupField(v, segment)
+			if !ok {
+				return fmt.Errorf("unknown key %q", segment)
+			}
+
+			v = field
+		case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= v.Len() {
+				return fmt.Errorf("invalid index %q for length %d", segment, v.Len())
+			}
+
+			v = v.Index(index)
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && i == len(segments)-1:
+			elem := reflect.New(v.Type().Elem()).Elem()
+
+			err := setOverride(elem, value)
+			if err != nil {
+				return err
+			}
+
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+
+			v.SetMapIndex(reflect.ValueOf(segment).Convert(v.Type().Key()), elem)
+
+			return nil
+		default:
+			return fmt.Errorf("unknown key %q", segment)
+		}
+	}
+
+	return setOverride(v, value)
+}
+
+// lookupField returns the exported field of the struct v matching name.
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := range v.NumField() {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		for _, n := range fieldNames(sf) {
+			if strings.EqualFold(n, name) {
+				return v.Field(i), true
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// setOverride stores value in v, converting it as described for
+// ApplyOverrides.
+func setOverride(v reflect.Value, value any) error {
+	if value == nil {
+		v.SetZero()
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch {
+	case rv.Type().AssignableTo(v.Type()):
+		v.Set(rv)
+	case isNumber(rv.Kind()) && isNumber(v.Kind()):
+		converted := rv.Convert(v.Type())
+		if !converted.Convert(rv.Type()).Equal(rv) {
+			return fmt.Errorf("value %v does not fit into %s", value, v.Type())
+		}
+
+		v.Set(converted)
+	case rv.Kind() == reflect.String:
+		return setFromString(v, rv.String())
+	default:
+		return fmt.Errorf("cannot use value of type %s as %s", rv.Type(), v.Type())
+	}
+
+	return nil
+}
+
+// isNumber reports whether k is an integer or floating-point kind.
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+package gonfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type overridesServer struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type overridesTLS struct {
+	Enabled bool
+}
+
+type overridesConfig struct {
+	Name     string `json:"name"`
+	Timeout  time.Duration
+	Database struct {
+		Port uint16 `json:"port"`
+		TLS  *overridesTLS
+	} `json:"database"`
+	Servers []overridesServer `json:"servers"`
+	Labels  map[string]string `json:"labels"`
+}
+
+func TestApplyOverrides(t *testing.T) {
+	c := overridesConfig{Servers: []overridesServer{{Host: "a", Port: 80}, {Host: "b", Port: 81}}}
+
+	err := ApplyOverrides(&c, map[string]any{
+		"name":                 "app",
+		"Timeout":              "5s",
+		"database.port":        5433,
+		"database.tls.enabled": "true",
+		"servers.1.port":       "8081",
+		"labels.env":           "prod",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := overridesConfig{
+		Name:    "app",
+		Timeout: 5 * time.Second,
+		Servers: []overridesServer{{Host: "a", Port: 80}, {Host: "b", Port: 8081}},
+		Labels:  map[string]string{"env": "prod"},
+	}
+	want.Database.Port = 5433
+	want.Database.TLS = &overridesTLS{Enabled: true}
+
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestApplyOverridesErrors(t *testing.T) {
+	c := overridesConfig{Servers: []overridesServer{{Host: "a"}}}
+
+	err := ApplyOverrides(&c, map[string]any{
+		"name":           "applied",
+		"database.user":  "admin",
+		"servers.3.port": 1,
+		"database.port":  70000,
+		"timeout":        "soon",
+	})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	for _, msg := range []string{`database.user: unknown key "user"`, "servers.3.port: invalid index", "database.port: value 70000 does not fit", "timeout"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q does not contain %q", err, msg)
+		}
+	}
+
+	if c.Name != "applied" {
+		t.Errorf("got name %q, want valid overrides applied regardless", c.Name)
+	}
+}
@@ -0,0 +1,180 @@
+package gonfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// XDGSearchPaths returns the search paths for the configuration file fileName
+// of the application appName according to the XDG Base Directory
+// Specification, ordered by priority.
+//
+// The user's configuration directory is $XDG_CONFIG_HOME, falling back to
+// $HOME/.config, and is followed by the directories in $XDG_CONFIG_DIRS,
+// falling back to /etc/xdg. Relative directories are ignored, as mandated by
+// the specification.
+func XDGSearchPaths(appName, fileName string) []string {
+	var paths []string
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configHome) {
+		configHome = ""
+
+		home := os.Getenv("HOME")
+		if filepath.IsAbs(home) {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, appName, fileName))
+	}
+
+	configDirs := os.Getenv("XDG_CONFIG_DIRS")
+	if configDirs == "" {
+		configDirs = "/etc/xdg"
+	}
+
+	for _, dir := range strings.Split(configDirs, ":") {
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(dir, appName, fileName))
+	}
+
+	return paths
+}
+
+// PlatformSearchPaths returns the search paths for the configuration file
+// fileName of the application appName according to the conventions of the
+// operating system, ordered by priority, with the user's configuration
+// directory first.
+//
+// On Windows, these are %APPDATA% followed by %ProgramData%. On macOS, these
+// are ~/Library/Application Support followed by /Library/Application Support.
+// On all other systems, the paths are those returned by XDGSearchPaths.
+// Directories whose environment variables are unset are omitted.
+func PlatformSearchPaths(appName, fileName string) []string {
+	var dirs []string
+
+	switch runtime.GOOS {
+	case "windows":
+		for _, env := range []string{"APPDATA", "ProgramData"} {
+			dir := os.Getenv(env)
+			if dir != "" {
+				dirs = append(dirs, dir)
+			}
+		}
+	case "darwin", "ios":
+		home := os.Getenv("HOME")
+		if home != "" {
+			dirs = append(dirs, filepath.Join(home, "Library", "Application Support"))
+		}
+
+		dirs = append(dirs, "/Library/Application Support")
+	default:
+		return XDGSearchPaths(appName, fileName)
+	}
+
+	paths := make([]string, len(dirs))
+	for i, dir := range dirs {
+		paths[i] = filepath.Join(dir, appName, fileName)
+	}
+
+	return paths
+}
+
+// PathFromEnv returns the primary path and search paths to pass to ReadConfig
+// or FindConfig based on the environment variable envVar.
+//
+// If envVar is set to a non-empty value, it is returned as the primary path
+// without any search paths, so that a nonexistent file is reported as an error
+// instead of silently falling back. Otherwise, an empty primary path and the
+// fallback search paths are returned.
+func PathFromEnv(envVar string, fallback []string) (primary string, search []string) {
+	primary = os.Getenv(envVar)
+	if primary != "" {
+		return primary, nil
+	}
+
+	return "", fallback
+}
+
+// ExpandPath expands a leading ~ in path to the current user's home directory
+// and replaces $VAR and ${VAR} with the values of the corresponding
+// environment variables using os.ExpandEnv. A ~ anywhere but at the start of
+// the path is left as is, as is a $ that does not start a variable name.
+//
+// Returns an error if path starts with ~ and the home directory cannot be
+// determined.
+func ExpandPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to expand %s: %w", path, err)
+		}
+
+		path = home + path[1:]
+	}
+
+	return os.ExpandEnv(path), nil
+}
+
+// ExpandPathTemplate replaces $VAR and ${VAR} in path with the values of the
+// corresponding variables as returned by lookup, e.g. os.LookupEnv. Returns
+// an error naming the variable if one is undefined, so that a missing
+// variable does not silently produce a wrong path.
+func ExpandPathTemplate(path string, lookup func(string) (string, bool)) (string, error) {
+	var undefined []string
+
+	expanded := os.Expand(path, func(name string) string {
+		value, ok := lookup(name)
+		if !ok {
+			undefined = append(undefined, name)
+		}
+
+		return value
+	})
+
+	if len(undefined) > 0 {
+		return "", fmt.Errorf("undefined variable %s in path %s", strings.Join(undefined, ", "), path)
+	}
+
+	return expanded, nil
+}
+
+// EnvSearchPaths returns the search paths for an environment-specific
+// configuration file, ordered by priority: baseName.env.ext followed by the
+// plain baseName.ext, e.g. config.prod.toml and config.toml. If env is empty,
+// only the plain path is returned. The extension may be given with or without
+// its leading dot.
+func EnvSearchPaths(baseName, ext, env string) []string {
+	ext = strings.TrimPrefix(ext, ".")
+
+	plain := baseName + "." + ext
+	if env == "" {
+		return []string{plain}
+	}
+
+	return []string{baseName + "." + env + "." + ext, plain}
+}
+
+// ExecutableDir returns the directory of the executable of the current
+// process, with symbolic links resolved, for use with WithBaseDir.
+func ExecutableDir() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine executable: %w", err)
+	}
+
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve executable: %w", err)
+	}
+
+	return filepath.Dir(exe), nil
+}
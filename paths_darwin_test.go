@@ -0,0 +1,17 @@
+package gonfig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlatformSearchPaths(t *testing.T) {
+	t.Setenv("HOME", "/Users/u")
+
+	want := []string{"/Users/u/Library/Application Support/app/config.toml", "/Library/Application Support/app/config.toml"}
+
+	got := PlatformSearchPaths("app", "config.toml")
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows && !darwin && !ios
+
+package gonfig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlatformSearchPaths(t *testing.T) {
+	t.Setenv("HOME", "/home/u")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CONFIG_DIRS", "/a")
+
+	want := []string{"/home/u/.config/app/config.toml", "/a/app/config.toml"}
+
+	got := PlatformSearchPaths("app", "config.toml")
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
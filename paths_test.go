@@ -0,0 +1,218 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestXDGSearchPaths(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("XDG paths are Unix paths")
+	}
+
+	tests := []struct {
+		name       string
+		configHome string
+		configDirs string
+		want       []string
+	}{
+		{"unset", "", "", []string{"/home/u/.config/app/config.toml", "/etc/xdg/app/config.toml"}},
+		{"config home", "/xdg/home", "", []string{"/xdg/home/app/config.toml", "/etc/xdg/app/config.toml"}},
+		{"config dirs", "", "/a:/b", []string{"/home/u/.config/app/config.toml", "/a/app/config.toml", "/b/app/config.toml"}},
+		{"both", "/xdg/home", "/a:/b", []string{"/xdg/home/app/config.toml", "/a/app/config.toml", "/b/app/config.toml"}},
+		{"relative ignored", "rel", "rel:/a", []string{"/home/u/.config/app/config.toml", "/a/app/config.toml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", "/home/u")
+			t.Setenv("XDG_CONFIG_HOME", tt.configHome)
+			t.Setenv("XDG_CONFIG_DIRS", tt.configDirs)
+
+			got := XDGSearchPaths("app", "config.toml")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	fallback := writeFile(t, dir, "config.json", `{}`)
+	fallbacks := []string{fallback}
+
+	t.Run("set", func(t *testing.T) {
+		explicit := writeFile(t, dir, "explicit.json", `{}`)
+		t.Setenv("APP_CONFIG", explicit)
+
+		primary, search := PathFromEnv("APP_CONFIG", fallbacks)
+		if primary != explicit || search != nil {
+			t.Errorf("got %q and %q, want %q without search paths", primary, search, explicit)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Setenv("APP_CONFIG", "")
+
+		primary, search := PathFromEnv("APP_CONFIG", fallbacks)
+		if primary != "" || !slices.Equal(search, fallbacks) {
+			t.Errorf("got %q and %q, want the fallback paths", primary, search)
+		}
+	})
+
+	t.Run("nonexistent", func(t *testing.T) {
+		t.Setenv("APP_CONFIG", filepath.Join(dir, "missing.json"))
+
+		_, err := FindConfig(PathFromEnv("APP_CONFIG", fallbacks))
+		if !errors.Is(err, ErrConfigNotFound) {
+			t.Errorf("got error %v, want ErrConfigNotFound rather than a fallback", err)
+		}
+	})
+}
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("APP_DIR", "/srv/app")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"~", home},
+		{"~/sub/config.json", filepath.Join(home, "sub", "config.json")},
+		{"$APP_DIR/config.json", "/srv/app/config.json"},
+		{"${APP_DIR}/config.json", "/srv/app/config.json"},
+		{"/etc/cost$.json", "/etc/cost$.json"},
+		{"/etc/~/config.json", "/etc/~/config.json"},
+		{"~user/config.json", "~user/config.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ExpandPath(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if filepath.ToSlash(got) != filepath.ToSlash(tt.want) {
+				t.Errorf("ExpandPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvSearchPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		ext  string
+		env  string
+		want []string
+	}{
+		{"set", "toml", "prod", []string{"config.prod.toml", "config.toml"}},
+		{"leading dot", ".toml", "dev", []string{"config.dev.toml", "config.toml"}},
+		{"unset", "toml", "", []string{"config.toml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EnvSearchPaths("config", tt.ext, tt.env)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvSearchPathsFallback(t *testing.T) {
+	dir := t.TempDir()
+	plain := writeFile(t, dir, "config.toml", ``)
+
+	got, err := FindConfig("", EnvSearchPaths(filepath.Join(dir, "config"), "toml", "prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != plain {
+		t.Errorf("got path %q, want the plain fallback %q", got, plain)
+	}
+
+	prod := writeFile(t, dir, "config.prod.toml", ``)
+
+	got, err = FindConfig("", EnvSearchPaths(filepath.Join(dir, "config"), "toml", "prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != prod {
+		t.Errorf("got path %q, want %q", got, prod)
+	}
+}
+
+func TestExecutableDir(t *testing.T) {
+	dir, err := ExecutableDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !filepath.IsAbs(dir) || dir != filepath.Dir(exe) {
+		t.Errorf("got %q, want the directory of %q", dir, exe)
+	}
+}
+
+func TestExpandPathTemplate(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"REGION": "eu-west-1", "EMPTY": ""}[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{"/etc/app/${REGION}/config.toml", "/etc/app/eu-west-1/config.toml", ""},
+		{"/etc/app/$REGION.toml", "/etc/app/eu-west-1.toml", ""},
+		{"/etc/app/${EMPTY}config.toml", "/etc/app/config.toml", ""},
+		{"/etc/app/config.toml", "/etc/app/config.toml", ""},
+		{"/etc/${APP}/${REGION}/${ENV}.toml", "", "undefined variable APP, ENV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ExpandPathTemplate(tt.path, lookup)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got %q and error %v, want an error containing %q", got, err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
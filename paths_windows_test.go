@@ -0,0 +1,31 @@
+package gonfig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlatformSearchPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		appData     string
+		programData string
+		want        []string
+	}{
+		{"both", `C:\Users\u\AppData\Roaming`, `C:\ProgramData`, []string{`C:\Users\u\AppData\Roaming\app\config.toml`, `C:\ProgramData\app\config.toml`}},
+		{"APPDATA unset", "", `C:\ProgramData`, []string{`C:\ProgramData\app\config.toml`}},
+		{"none set", "", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APPDATA", tt.appData)
+			t.Setenv("ProgramData", tt.programData)
+
+			got := PlatformSearchPaths("app", "config.toml")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
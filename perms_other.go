@@ -0,0 +1,12 @@
+//go:build !unix
+
+package gonfig
+
+import (
+	"io/fs"
+)
+
+// checkPerms is a no-op on platforms without Unix permissions.
+func checkPerms(string, fs.FileInfo, *options) error {
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package gonfig
+
+import "testing"
+
+func TestPermChecksSkipped(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{}`)
+
+	var c testConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, RequireOwner(), RequireSecurePerms(), RequireNonWritableByOthers())
+	if err != nil {
+		t.Errorf("got error %v, want the checks to be skipped", err)
+	}
+}
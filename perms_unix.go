@@ -0,0 +1,37 @@
+//go:build unix
+
+package gonfig
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// checkPerms checks the permissions of the configuration file at path
+// described by info against the requirements of o.
+func checkPerms(path string, info fs.FileInfo, o *options) error {
+	if o == nil {
+		return nil
+	}
+
+	perm := info.Mode().Perm()
+
+	if o.securePerms && perm&0o007 != 0 {
+		return fmt.Errorf("configuration file %s is accessible by others (mode %s)", path, perm)
+	}
+
+	if o.noWriteOthers && perm&0o022 != 0 {
+		return fmt.Errorf("configuration file %s is writable by others (mode %s)", path, perm)
+	}
+
+	if o.requireOwner {
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if ok && int(st.Uid) != os.Getuid() {
+			return fmt.Errorf("configuration file %s is owned by uid %d instead of uid %d", path, st.Uid, os.Getuid())
+		}
+	}
+
+	return nil
+}
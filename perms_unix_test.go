@@ -0,0 +1,189 @@
+//go:build unix
+
+package gonfig
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRequireSecurePerms(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		mode    os.FileMode
+		wantErr bool
+	}{
+		{0o600, false},
+		{0o640, false},
+		{0o644, true},
+		{0o606, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			path := writeFile(t, dir, "config.json", `{}`)
+
+			err := os.Chmod(path, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var c testConfig
+
+			_, err = ReadConfig(path, nil, &c, JSON[testConfig](), nil, RequireSecurePerms())
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), "accessible by others") {
+				t.Errorf("got error %v, want a permission error", err)
+			}
+		})
+	}
+}
+
+func TestRequireOwner(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("matching", func(t *testing.T) {
+		path := writeFile(t, dir, "own.json", `{}`)
+
+		var c testConfig
+
+		_, err := ReadConfig(path, nil, &c, JSON[testConfig](), nil, RequireOwner())
+		if err != nil {
+			t.Errorf("got error %v, want none", err)
+		}
+	})
+
+	t.Run("mismatching", func(t *testing.T) {
+		if os.Getuid() != 0 {
+			t.Skip("changing the owner of a file requires root")
+		}
+
+		path := writeFile(t, dir, "other.json", `{}`)
+
+		err := os.Chown(path, 65534, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var c testConfig
+
+		_, err = ReadConfig(path, nil, &c, JSON[testConfig](), nil, RequireOwner())
+		if err == nil || !strings.Contains(err.Error(), "owned by uid 65534") {
+			t.Errorf("got error %v, want an ownership error", err)
+		}
+	})
+}
+
+func TestRequireNonWritableByOthers(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		mode    os.FileMode
+		wantErr bool
+	}{
+		{0o644, false},
+		{0o640, false},
+		{0o664, true},
+		{0o666, true},
+		{0o642, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			path := writeFile(t, dir, "config.json", `{}`)
+
+			err := os.Chmod(path, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var c testConfig
+
+			_, err = ReadConfig(path, nil, &c, JSON[testConfig](), nil, RequireNonWritableByOthers())
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), "writable by others") {
+				t.Errorf("got error %v, want a permission error", err)
+			}
+		})
+	}
+}
+
+func TestReadConfigAllChecksPerms(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "jobs.jsonl", `{"Name": "a"}`)
+
+	err := os.Chmod(path, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ReadConfigAll[testConfig](path, JSONDecoder(), nil, RequireSecurePerms())
+	if err == nil || !strings.Contains(err.Error(), "accessible by others") {
+		t.Errorf("got error %v, want a permission error", err)
+	}
+}
+
+func TestFileSourceChecksPerms(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "file"}`)
+
+	err := os.Chmod(path, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FileSource{Path: path, Options: []Option{RequireSecurePerms()}}.Read(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "accessible by others") {
+		t.Errorf("got error %v, want a permission error", err)
+	}
+}
+
+func TestCaptureLoadChecksPerms(t *testing.T) {
+	tests := []struct {
+		name               string
+		mode, overrideMode os.FileMode
+	}{
+		{"configuration file", 0o644, 0o600},
+		{"local override file", 0o600, 0o644},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := writeFile(t, dir, "config.json", `{"Name": "app"}`)
+			local := writeFile(t, dir, "config.local.json", `{"Port": 9090}`)
+
+			err := os.Chmod(path, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = os.Chmod(local, tt.overrideMode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var c testConfig
+
+			_, err = CaptureLoad(path, nil, &c, JSON[testConfig](), nil, "", WithLocalOverride(), RequireSecurePerms())
+			if err == nil || !strings.Contains(err.Error(), "accessible by others") {
+				t.Errorf("got error %v, want a permission error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package gonfig
+
+import "errors"
+
+// Pipeline describes the stages run by ReadConfigPipeline after the
+// configuration file has been read. Stages that are not set are skipped.
+type Pipeline[T any] struct {
+	// Unmarshal unmarshals the content of the configuration file.
+	Unmarshal UnmarshalFunc[*T]
+	// Defaults enables applying the default struct tags using ApplyDefaults.
+	Defaults bool
+	// Normalize normalizes the values, e.g. using AbsolutizePaths.
+	Normalize func(*T) error
+	// Env enables overriding fields with environment variables using
+	// ApplyEnv with EnvPrefix.
+	Env       bool
+	EnvPrefix string
+	// Validate validates the configuration object.
+	Validate FinalizeFunc[*T]
+}
+
+// Names of the stages of a Pipeline as reported by StageError.
+const (
+	StageUnmarshal = "unmarshal"
+	StageDefaults  = "defaults"
+	StageNormalize = "normalize"
+	StageEnv       = "env"
+	StageValidate  = "validate"
+)
+
+// ReadConfigPipeline is like ReadConfig, but runs the stages of p in a fixed
+// order: the content is unmarshaled, the default values are applied, the
+// values are normalized, environment variables are applied, and the result is
+// validated. This spares callers from sequencing these steps themselves.
+//
+// The first failing stage halts the pipeline, and its error is returned as a
+// *StageError naming the stage. Unmarshaling errors are reported as
+// *UnmarshalError as usual. As with ReadConfig, c is only updated if all
+// stages succeed.
+func ReadConfigPipeline[T any](path string, searchPaths []string, c *T, p Pipeline[T], opts ...Option) (string, error) {
+	return ReadConfig(path, searchPaths, c, p.Unmarshal, p.finalize(), opts...)
+}
+
+// PipelineResult is returned by ReadConfigPipelineAll. It carries the errors
+// of all stages, so that tooling can render them grouped by stage.
+type PipelineResult struct {
+	// Path is the resolved path of the configuration file.
+	Path string
+	// Errors holds the errors of all failing stages in the order of the
+	// stages. Joined errors, e.g. from CombineValidators, are split into one
+	// StageError each.
+	Errors []StageError
+}
+
+// Stage returns the errors of the stage with the given name.
+func (r PipelineResult) Stage(name string) []StageError {
+	var errs []StageError
+
+	for _, e := range r.Errors {
+		if e.Stage == name {
+			errs = append(errs, e)
+		}
+	}
+
+	return errs
+}
+
+// ReadConfigPipelineAll is like ReadConfigPipeline, but does not halt at the
+// first failing stage. Instead, all stages run, and their errors are returned
+// individually in the result, which complements the joined error for
+// programmatic consumers. The returned error joins the *StageError values,
+// or reports that the configuration file could not be located or read, in
+// which case the result holds no errors. An unmarshaling error is reported as
+// the only error of the StageUnmarshal stage, since the remaining stages
+// cannot run without a configuration object. As with ReadConfig, c is only
+// updated if all stages succeed.
+func ReadConfigPipelineAll[T any](path string, searchPaths []string, c *T, p Pipeline[T], opts ...Option) (PipelineResult, error) {
+	var r PipelineResult
+
+	var err error
+
+	r.Path, err = ReadConfig(path, searchPaths, c, p.Unmarshal, func(c *T) error {
+		for _, s := range p.stages() {
+			err := s.run(c)
+			if err == nil {
+				continue
+			}
+
+			for _, err := range splitErrors(err) {
+				r.Errors = append(r.Errors, StageError{Stage: s.name, Err: err})
+			}
+		}
+
+		return r.err()
+	}, opts...)
+
+	var ue *UnmarshalError
+	if len(r.Errors) == 0 && errors.As(err, &ue) {
+		r.Errors = []StageError{{Stage: StageUnmarshal, Err: ue}}
+		return r, r.err()
+	}
+
+	return r, err
+}
+
+// err joins the errors of r.
+func (r PipelineResult) err() error {
+	var errs []error
+
+	for i := range r.Errors {
+		errs = append(errs, &r.Errors[i])
+	}
+
+	return errors.Join(errs...)
+}
+
+// splitErrors splits errors joined by errors.Join or CombineValidators,
+// including nested ones.
+func splitErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, splitErrors(err)...)
+	}
+
+	return errs
+}
+
+// pipelineStage is a named stage of a Pipeline.
+type pipelineStage[T any] struct {
+	name string
+	run  func(*T) error
+}
+
+// stages returns the stages of p following unmarshaling.
+func (p Pipeline[T]) stages() []pipelineStage[T] {
+	var stages []pipelineStage[T]
+
+	if p.Defaults {
+		stages = append(stages, pipelineStage[T]{StageDefaults, ApplyDefaults[T]})
+	}
+
+	if p.Normalize != nil {
+		stages = append(stages, pipelineStage[T]{StageNormalize, p.Normalize})
+	}
+
+	if p.Env {
+		stages = append(stages, pipelineStage[T]{StageEnv, func(c *T) error {
+			return ApplyEnv(c, p.EnvPrefix)
+		}})
+	}
+
+	if p.Validate != nil {
+		stages = append(stages, pipelineStage[T]{StageValidate, p.Validate})
+	}
+
+	return stages
+}
+
+// finalize returns a FinalizeFunc running the stages of p following
+// unmarshaling, halting at the first failing stage.
+func (p Pipeline[T]) finalize() FinalizeFunc[*T] {
+	stages := p.stages()
+
+	return func(c *T) error {
+		for _, s := range stages {
+			err := s.run(c)
+			if err != nil {
+				return &StageError{Stage: s.name, Err: err}
+			}
+		}
+
+		return nil
+	}
+}
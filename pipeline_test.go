@@ -0,0 +1,186 @@
+package gonfig
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type pipelineConfig struct {
+	Name string
+	Host string `default:"Example.COM"`
+	Port int
+}
+
+func TestReadConfigPipeline(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "file"}`)
+
+	t.Setenv("APP_PORT", "8080")
+
+	var stages []string
+
+	p := Pipeline[pipelineConfig]{
+		Unmarshal: JSON[pipelineConfig](),
+		Defaults:  true,
+		Normalize: func(c *pipelineConfig) error {
+			stages = append(stages, "normalize")
+
+			if c.Host != "Example.COM" || c.Port != 0 {
+				t.Errorf("got %+v in normalize, want defaults applied before and environment after it", c)
+			}
+
+			c.Host = strings.ToLower(c.Host)
+
+			return nil
+		},
+		Env:       true,
+		EnvPrefix: "APP",
+		Validate: func(c *pipelineConfig) error {
+			stages = append(stages, "validate")
+
+			if c.Host != "example.com" || c.Port != 8080 {
+				t.Errorf("got %+v in validate, want all other stages run before it", c)
+			}
+
+			return nil
+		},
+	}
+
+	var c pipelineConfig
+
+	_, err := ReadConfigPipeline(path, nil, &c, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"normalize", "validate"}; !slices.Equal(stages, want) {
+		t.Errorf("got stages %q, want %q", stages, want)
+	}
+
+	if want := (pipelineConfig{Name: "file", Host: "example.com", Port: 8080}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestReadConfigPipelineHalts(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "file"}`)
+
+	errNormalize := errors.New("cannot normalize")
+
+	p := Pipeline[pipelineConfig]{
+		Unmarshal: JSON[pipelineConfig](),
+		Normalize: func(*pipelineConfig) error { return errNormalize },
+		Validate: func(*pipelineConfig) error {
+			t.Error("validate ran after a failing stage")
+			return nil
+		},
+	}
+
+	c := pipelineConfig{Name: "initial"}
+
+	_, err := ReadConfigPipeline(path, nil, &c, p)
+
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != StageNormalize {
+		t.Fatalf("got error %v, want a *StageError for the normalize stage", err)
+	}
+
+	if !errors.Is(err, errNormalize) || !strings.Contains(err.Error(), "normalize stage failed") {
+		t.Errorf("got error %v, want it to wrap errNormalize with the stage", err)
+	}
+
+	if c != (pipelineConfig{Name: "initial"}) {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+
+	malformed := writeFile(t, t.TempDir(), "config.json", `{`)
+
+	_, err = ReadConfigPipeline(malformed, nil, &c, p)
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Errorf("got error %v, want an *UnmarshalError", err)
+	}
+}
+
+func TestReadConfigPipelineAll(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "file"}`)
+
+	errNormalize := errors.New("cannot normalize host")
+	errName, errPort := errors.New("name is reserved"), errors.New("port is not set")
+
+	p := Pipeline[pipelineConfig]{
+		Unmarshal: JSON[pipelineConfig](),
+		Defaults:  true,
+		Normalize: func(*pipelineConfig) error { return errNormalize },
+		Validate: CombineValidators(
+			func(*pipelineConfig) error { return errName },
+			func(*pipelineConfig) error { return nil },
+			func(*pipelineConfig) error { return errPort },
+		),
+	}
+
+	c := pipelineConfig{Name: "initial"}
+
+	r, err := ReadConfigPipelineAll(path, nil, &c, p)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	if r.Path != path {
+		t.Errorf("got path %q, want %q", r.Path, path)
+	}
+
+	if len(r.Errors) != 3 {
+		t.Fatalf("got errors %v, want 3", r.Errors)
+	}
+
+	if got := r.Stage(StageNormalize); len(got) != 1 || !errors.Is(got[0].Err, errNormalize) {
+		t.Errorf("got normalize errors %v, want errNormalize", got)
+	}
+
+	got := r.Stage(StageValidate)
+	if len(got) != 2 || !errors.Is(got[0].Err, errName) || !errors.Is(got[1].Err, errPort) {
+		t.Errorf("got validate errors %v, want errName and errPort", got)
+	}
+
+	if len(r.Stage(StageDefaults)) != 0 {
+		t.Errorf("got defaults errors %v, want none", r.Stage(StageDefaults))
+	}
+
+	for _, want := range []error{errNormalize, errName, errPort} {
+		if !errors.Is(err, want) {
+			t.Errorf("got error %v, want it to match %v", err, want)
+		}
+	}
+
+	if c != (pipelineConfig{Name: "initial"}) {
+		t.Errorf("got %+v, want the initial configuration", c)
+	}
+}
+
+func TestReadConfigPipelineAllUnmarshal(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{`)
+
+	p := Pipeline[pipelineConfig]{Unmarshal: JSON[pipelineConfig]()}
+
+	r, err := ReadConfigPipelineAll(path, nil, &pipelineConfig{}, p)
+
+	var unmarshalErr *UnmarshalError
+	if len(r.Errors) != 1 || r.Errors[0].Stage != StageUnmarshal || !errors.As(err, &unmarshalErr) {
+		t.Errorf("got errors %v and error %v, want a single unmarshal error", r.Errors, err)
+	}
+
+	r, err = ReadConfigPipelineAll(filepath.Join(dir, "missing.json"), nil, &pipelineConfig{}, p)
+	if !errors.Is(err, ErrConfigNotFound) || len(r.Errors) != 0 {
+		t.Errorf("got errors %v and error %v, want a not found error only", r.Errors, err)
+	}
+
+	r, err = ReadConfigPipelineAll(writeFile(t, dir, "valid.json", `{"Name": "a"}`), nil, &pipelineConfig{}, p)
+	if err != nil || len(r.Errors) != 0 {
+		t.Errorf("got errors %v and error %v, want none", r.Errors, err)
+	}
+}
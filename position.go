@@ -0,0 +1,63 @@
+package gonfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// positionFunc extracts the 1-based line and column at which err occurred
+// while unmarshaling content. A column of 0 means the column is unknown.
+// Returns false if err does not carry position information.
+type positionFunc func(err error, content []byte) (line, column int, ok bool)
+
+// positionFuncs holds the position extractors of all supported formats.
+var positionFuncs = []positionFunc{jsonPosition}
+
+// position returns the 1-based line and column at which err occurred while
+// unmarshaling content, or zero values if unknown.
+func position(err error, content []byte) (line, column int) {
+	for _, pos := range positionFuncs {
+		line, column, ok := pos(err, content)
+		if ok {
+			return line, column
+		}
+	}
+
+	return 0, 0
+}
+
+// jsonPosition extracts the position from encoding/json errors by converting
+// their byte offset, which points just past the offending byte.
+func jsonPosition(err error, content []byte) (int, int, bool) {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		offset    int64
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return 0, 0, false
+	}
+
+	line, column := offsetPosition(content, offset-1)
+
+	return line, column, true
+}
+
+// offsetPosition converts the 0-based byte offset into content to a 1-based
+// line and column.
+func offsetPosition(content []byte, offset int64) (line, column int) {
+	offset = max(0, min(offset, int64(len(content))))
+	before := content[:offset]
+
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = len(before) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
@@ -0,0 +1,59 @@
+package gonfig
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOffsetPosition(t *testing.T) {
+	content := []byte("ab\ncd\n\nef")
+
+	tests := []struct {
+		offset       int64
+		line, column int
+	}{
+		{0, 1, 1},
+		{1, 1, 2},
+		{3, 2, 1},
+		{4, 2, 2},
+		{7, 4, 1},
+		{9, 4, 3},
+		{-1, 1, 1},
+		{100, 4, 3},
+	}
+
+	for _, tt := range tests {
+		line, column := offsetPosition(content, tt.offset)
+		if line != tt.line || column != tt.column {
+			t.Errorf("offsetPosition(%d) = %d:%d, want %d:%d", tt.offset, line, column, tt.line, tt.column)
+		}
+	}
+}
+
+func TestJSONPosition(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		line, column int
+	}{
+		{"syntax error", "{\n  \"Name\": \"app\",\n  \"Port\": x\n}", 3, 11},
+		{"type error", "{\n  \"Port\": \"80\"\n}", 2, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c testConfig
+
+			err := ReadConfigBytes([]byte(tt.content), &c, JSON[testConfig](), nil)
+
+			var unmarshalErr *UnmarshalError
+			if !errors.As(err, &unmarshalErr) {
+				t.Fatalf("got error %v, want an *UnmarshalError", err)
+			}
+
+			if unmarshalErr.Line != tt.line || unmarshalErr.Column != tt.column {
+				t.Errorf("got position %d:%d, want %d:%d", unmarshalErr.Line, unmarshalErr.Column, tt.line, tt.column)
+			}
+		})
+	}
+}
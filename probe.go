@@ -0,0 +1,45 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// PathStatus describes a candidate path of a configuration file as reported
+// by ProbePaths.
+type PathStatus struct {
+	// Path is the candidate path.
+	Path string
+	// Exists reports whether a file exists at Path.
+	Exists bool
+	// Err holds the reason why Path cannot be used as a configuration file,
+	// e.g. a permission error or because it is a directory, or is nil if it
+	// either can be used or does not exist.
+	Err error
+}
+
+// ProbePaths checks every one of the given candidate paths, e.g. to let a
+// diagnostic command show where the application looks for its configuration
+// file and what it finds there. Unlike FindConfig, it does not stop at the
+// first usable path, and glob patterns are checked as literal paths.
+func ProbePaths(paths []string) []PathStatus {
+	statuses := make([]PathStatus, len(paths))
+
+	for i, path := range paths {
+		statuses[i].Path = path
+
+		info, err := os.Stat(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			statuses[i].Err = fmt.Errorf("could not stat configuration file %s: %w", path, err)
+		default:
+			statuses[i].Exists = true
+			statuses[i].Err = checkRegular(path, info)
+		}
+	}
+
+	return statuses
+}
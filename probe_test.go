@@ -0,0 +1,70 @@
+package gonfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestProbePaths(t *testing.T) {
+	dir := t.TempDir()
+	existing := writeFile(t, dir, "config.json", `{}`)
+	missing := filepath.Join(dir, "missing.json")
+
+	got := ProbePaths([]string{existing, missing, dir, filepath.Join(dir, "*.json")})
+
+	want := []struct {
+		exists bool
+		err    bool
+	}{
+		{true, false},
+		{false, false},
+		{true, true},
+		{false, false},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d statuses, want %d", len(got), len(want))
+	}
+
+	for i, w := range want {
+		if got[i].Exists != w.exists || (got[i].Err != nil) != w.err {
+			t.Errorf("got %+v, want exists %t and error %t", got[i], w.exists, w.err)
+		}
+	}
+
+	if got[2].Err == nil || !strings.Contains(got[2].Err.Error(), "is a directory") {
+		t.Errorf("got error %v for a directory, want it to report the directory", got[2].Err)
+	}
+}
+
+func TestProbePathsPermissionDenied(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced")
+	}
+
+	sub := filepath.Join(t.TempDir(), "sub")
+
+	err := os.Mkdir(sub, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeFile(t, sub, "config.json", `{}`)
+
+	err = os.Chmod(sub, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(sub, 0o755) })
+
+	got := ProbePaths([]string{path})
+
+	if got[0].Exists || !errors.Is(got[0].Err, fs.ErrPermission) {
+		t.Errorf("got %+v, want a permission error", got[0])
+	}
+}
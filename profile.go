@@ -0,0 +1,65 @@
+package gonfig
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ReadProfile reads the configuration file at path, which holds named
+// profiles as top-level keys, unmarshals the given profile into the given
+// configuration object, and validates it.
+//
+// A profile may inherit from another profile by naming it in its extends key,
+// e.g. extends = "base" in TOML. The chain of inherited profiles is merged as
+// described for Merge, starting at the root, so that every profile overrides
+// the profiles it extends. The content is decoded using decode, e.g.
+// json.Unmarshal, as described for ReadSection. Returns an error if a profile
+// does not exist or if the chain contains a cycle.
+func ReadProfile[T any](path string, profile string, c *T, decode func([]byte, any) error, finalize FinalizeFunc[*T], opts ...Option) error {
+	unmarshal := func(content []byte, c *T) error {
+		var profiles map[string]map[string]any
+
+		err := decode(content, &profiles)
+		if err != nil {
+			return err
+		}
+
+		var chain []string
+
+		for name := profile; name != ""; {
+			if slices.Contains(chain, name) {
+				return fmt.Errorf("cyclic profile inheritance: %s -> %s", strings.Join(chain, " -> "), name)
+			}
+
+			p, ok := profiles[name]
+			if !ok {
+				return fmt.Errorf("missing profile %q", name)
+			}
+
+			chain = append(chain, name)
+
+			extends, ok := p["extends"].(string)
+			if !ok && p["extends"] != nil {
+				return fmt.Errorf("profile %q extends a non-string value", name)
+			}
+
+			name = extends
+		}
+
+		for _, name := range slices.Backward(chain) {
+			var layer T
+
+			err = sectionUnmarshal[T](name, decode)(content, &layer)
+			if err != nil {
+				return err
+			}
+
+			Merge(c, &layer)
+		}
+
+		return nil
+	}
+
+	return ReadFoundConfig(path, c, unmarshal, finalize, opts...)
+}
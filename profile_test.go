@@ -0,0 +1,79 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type profileConfig struct {
+	Host  string `json:"host"`
+	Port  int    `json:"port"`
+	Debug bool   `json:"debug"`
+}
+
+func TestReadProfile(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "profiles.json", `{
+		"base": {"host": "localhost", "port": 8080},
+		"staging": {"extends": "base", "host": "staging.example.com"},
+		"dev": {"extends": "staging", "port": 9090, "debug": true}
+	}`)
+
+	tests := []struct {
+		profile string
+		want    profileConfig
+	}{
+		{"base", profileConfig{Host: "localhost", Port: 8080}},
+		{"staging", profileConfig{Host: "staging.example.com", Port: 8080}},
+		{"dev", profileConfig{Host: "staging.example.com", Port: 9090, Debug: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			var c profileConfig
+
+			err := ReadProfile(path, tt.profile, &c, json.Unmarshal, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c != tt.want {
+				t.Errorf("got %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadProfileErrors(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "profiles.json", `{
+		"a": {"extends": "b", "port": 1},
+		"b": {"extends": "a", "port": 2},
+		"orphan": {"extends": "missing"},
+		"numeric": {"extends": 1}
+	}`)
+
+	tests := []struct {
+		profile string
+		wantErr string
+	}{
+		{"a", "cyclic profile inheritance: a -> b -> a"},
+		{"orphan", `missing profile "missing"`},
+		{"unknown", `missing profile "unknown"`},
+		{"numeric", "non-string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			c := profileConfig{Host: "initial"}
+
+			err := ReadProfile(path, tt.profile, &c, json.Unmarshal, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+
+			if c != (profileConfig{Host: "initial"}) {
+				t.Errorf("got %+v, want the initial configuration", c)
+			}
+		})
+	}
+}
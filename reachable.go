@@ -0,0 +1,75 @@
+package gonfig
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Reachable returns a FinalizeFunc that checks whether the address returned
+// by get can actually be connected to, e.g. to fail at startup if the
+// configured database is unreachable. Unlike other validators, it performs
+// network I/O, bounded by the deadline of ctx, so it must be opted into
+// explicitly.
+//
+// HTTP and HTTPS URLs are checked using a HEAD request, where any response
+// counts as reachable regardless of its status. For URLs with other schemes,
+// e.g. postgres://db:5432/app, a TCP connection to the host and port is
+// established. All other values are dialed as a TCP host:port address. Empty
+// values are skipped; use RequiredFields to require them.
+func Reachable[T any](ctx context.Context, get func(T) string) FinalizeFunc[T] {
+	return func(c T) error {
+		addr := get(c)
+		if addr == "" {
+			return nil
+		}
+
+		err := reach(ctx, addr)
+		if err != nil {
+			// Keep passwords in URLs out of the error.
+			u, perr := url.Parse(addr)
+			if perr == nil && u.User != nil {
+				addr = u.Redacted()
+			}
+
+			return fmt.Errorf("%s is not reachable: %w", addr, err)
+		}
+
+		return nil
+	}
+}
+
+// reach connects to addr as described for Reachable.
+func reach(ctx context.Context, addr string) error {
+	u, err := url.Parse(addr)
+	if err == nil && u.Scheme != "" && u.Host != "" {
+		switch strings.ToLower(u.Scheme) {
+		case "http", "https":
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, addr, nil)
+			if err != nil {
+				return err
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return err
+			}
+
+			return resp.Body.Close()
+		default:
+			addr = u.Host
+		}
+	}
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,78 @@
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	downAddr := down.Addr().String()
+	down.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"listener up", ln.Addr().String(), false},
+		{"URL up", "postgres://" + ln.Addr().String() + "/app", false},
+		{"HTTP up", srv.URL, false},
+		{"listener down", downAddr, true},
+		{"URL down", "postgres://" + downAddr + "/app", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := Reachable(ctx, func(addr string) string { return addr })(tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReachableTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	u := strings.Replace(srv.URL, "http://", "http://user:hunter2@", 1)
+
+	err := Reachable(ctx, func(addr string) string { return addr })(u)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want it to match context.DeadlineExceeded", err)
+	}
+
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("error %q contains the password", err)
+	}
+}
@@ -0,0 +1,203 @@
+package gonfig
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces the values of secret fields.
+const redactedValue = "****"
+
+// Redacted returns a string representation of the configuration object,
+// similar to the %+v verb of the fmt package, in which the values of fields
+// tagged with `gonfig:"secret"` or `gonfig:"secretfile"` are replaced with
+// ****. Secret fields are masked within nested structs, pointers, slices,
+// arrays, and maps as well. Unexported fields are omitted.
+//
+// This is meant for logging the configuration, e.g.
+//
+//	log.Printf("config: %s", gonfig.Redacted(cfg))
+func Redacted[T any](c T) string {
+	var b strings.Builder
+
+	writeRedacted(&b, reflect.ValueOf(&c).Elem())
+
+	return b.String()
+}
+
+// ReadAndRedact reads the configuration file at path, masks the values of
+// fields tagged with `gonfig:"secret"` or `gonfig:"secretfile"` as Redacted
+// does, and marshals the result using marshal, e.g. to produce a copy of the
+// configuration that users can safely attach to a support request while its
+// structure is preserved.
+//
+// Secret string fields are set to ****, while secret fields of other types
+// are set to their zero value, so that the result still marshals into the
+// same format. As with Format, the configuration is not validated, so that
+// broken configurations can be shared as well.
+func ReadAndRedact[T any](path string, unmarshal UnmarshalFunc[*T], marshal MarshalFunc[*T]) ([]byte, error) {
+	var c T
+
+	err := ReadFoundConfig(path, &c, unmarshal, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	redactValue(reflect.ValueOf(&c).Elem())
+
+	return marshal(&c)
+}
+
+// redactValue masks the secret fields within v in place. Values held by maps
+// and interfaces are copied before they are masked.
+func redactValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		redactValue(elem)
+		v.Set(elem)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			redactValue(v.Elem())
+		}
+	case reflect.Struct:
+		if !isStruct(v.Type()) {
+			return
+		}
+
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			if tag := parseTag(sf); tag.has("secret") || tag.has("secretfile") {
+				maskValue(v.Field(i))
+			} else {
+				redactValue(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			redactValue(v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			redactValue(elem)
+			v.SetMapIndex(key, elem)
+		}
+	}
+}
+
+// maskValue replaces the value of the secret field v with ****, or with its
+// zero value unless it is a string or a pointer to a string.
+func maskValue(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(redactedValue)
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String && !v.IsNil():
+		p := reflect.New(v.Type().Elem())
+		p.Elem().SetString(redactedValue)
+		v.Set(p)
+	default:
+		v.SetZero()
+	}
+}
+
+func writeRedacted(b *strings.Builder, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+
+		writeRedacted(b, v.Elem())
+	case reflect.Pointer:
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+
+		b.WriteByte('&')
+		writeRedacted(b, v.Elem())
+	case reflect.Struct:
+		if !isStruct(v.Type()) {
+			fmt.Fprint(b, v.Interface())
+			return
+		}
+
+		b.WriteByte('{')
+
+		first := true
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			if !first {
+				b.WriteByte(' ')
+			}
+			first = false
+
+			b.WriteString(sf.Name)
+			b.WriteByte(':')
+
+			if tag := parseTag(sf); tag.has("secret") || tag.has("secretfile") {
+				b.WriteString(redactedValue)
+			} else {
+				writeRedacted(b, v.Field(i))
+			}
+		}
+
+		b.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprint(b, v.Interface())
+			return
+		}
+
+		b.WriteByte('[')
+
+		for i := range v.Len() {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+
+			writeRedacted(b, v.Index(i))
+		}
+
+		b.WriteByte(']')
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+
+		b.WriteString("map[")
+
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+
+			writeRedacted(b, key)
+			b.WriteByte(':')
+			writeRedacted(b, v.MapIndex(key))
+		}
+
+		b.WriteByte(']')
+	default:
+		fmt.Fprint(b, v.Interface())
+	}
+}
@@ -0,0 +1,82 @@
+package gonfig
+
+import (
+	"strings"
+	"testing"
+)
+
+type redactConfig struct {
+	Name     string
+	Password string `gonfig:"secret"`
+	Database struct {
+		Host  string
+		Token string `gonfig:"secret"`
+	}
+	Users []redactUser
+}
+
+type redactUser struct {
+	Login  string
+	APIKey string `gonfig:"secret"`
+}
+
+func TestRedacted(t *testing.T) {
+	var c redactConfig
+	c.Name = "app"
+	c.Password = "hunter2"
+	c.Database.Host = "db.internal"
+	c.Database.Token = "db-token"
+	c.Users = []redactUser{{Login: "alice", APIKey: "alice-key"}}
+
+	got := Redacted(c)
+
+	want := "{Name:app Password:**** Database:{Host:db.internal Token:****} Users:[{Login:alice APIKey:****}]}"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	for _, secret := range []string{"hunter2", "db-token", "alice-key"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("%s leaks %q", got, secret)
+		}
+	}
+
+	if c.Password != "hunter2" {
+		t.Error("Redacted modified its argument")
+	}
+}
+
+func TestReadAndRedact(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":"app","Password":"hunter2","Database":{"Host":"db.internal","Token":"db-token"},"Users":[{"Login":"alice","APIKey":"alice-key"}]}`)
+
+	got, err := ReadAndRedact(path, JSON[redactConfig](), MarshalJSON[redactConfig]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"hunter2", "db-token", "alice-key"} {
+		if strings.Contains(string(got), secret) {
+			t.Errorf("%s leaks %q", got, secret)
+		}
+	}
+
+	var c redactConfig
+
+	err = ReadConfigBytes(got, &c, JSON[redactConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" || c.Password != "****" || c.Database.Host != "db.internal" || c.Database.Token != "****" {
+		t.Errorf("got %+v, want the structure preserved with secrets masked", c)
+	}
+
+	if len(c.Users) != 1 || c.Users[0].Login != "alice" || c.Users[0].APIKey != "****" {
+		t.Errorf("got users %+v", c.Users)
+	}
+
+	_, err = ReadAndRedact(writeFile(t, t.TempDir(), "bad.json", `{`), JSON[redactConfig](), MarshalJSON[redactConfig]())
+	if err == nil {
+		t.Error("got no error for malformed content")
+	}
+}
@@ -0,0 +1,215 @@
+package gonfig
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// visitFunc is called by walkFields for each visited field. The path holds the
+// struct fields leading to the visited field, including the field itself.
+type visitFunc func(path []reflect.StructField, v reflect.Value) error
+
+// walkFields calls fn for every exported field of the struct v, recursing
+// into nested structs and non-nil pointers to structs.
+func walkFields(v reflect.Value, fn visitFunc) error {
+	return walkFieldsPath(v, nil, fn)
+}
+
+func walkFieldsPath(v reflect.Value, path []reflect.StructField, fn visitFunc) error {
+	t := v.Type()
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		p := append(path[:len(path):len(path)], sf)
+
+		err := fn(p, fv)
+		if err != nil {
+			return err
+		}
+
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+
+		if isStruct(fv.Type()) {
+			err = walkFieldsPath(fv, p, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isStruct reports whether t is a struct that is walked field by field rather
+// than treated as a single value.
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// isLeaf reports whether a field of type t holds a single value, i.e. it is
+// neither a struct nor a pointer to a struct that is walked field by field.
+func isLeaf(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return !isStruct(t)
+}
+
+// setFromString parses s and stores the result in v.
+//
+// Supported are strings, booleans, integers, floats, time.Duration, types
+// implementing encoding.TextUnmarshaler, pointers to any of these, and
+// slices of any of these given as comma-separated lists.
+func setFromString(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return setFromString(v.Elem(), s)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(n)
+	case reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, ",")
+		}
+
+		sl := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			err := setFromString(sl.Index(i), strings.TrimSpace(part))
+			if err != nil {
+				return err
+			}
+		}
+
+		v.Set(sl)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// fieldPath returns the dotted path of the field at the given path, e.g.
+// Database.URL.
+func fieldPath(path []reflect.StructField) string {
+	names := make([]string, len(path))
+	for i, sf := range path {
+		names[i] = sf.Name
+	}
+
+	return strings.Join(names, ".")
+}
+
+// stringValues returns the strings held by v, which is a string, a slice or
+// array of strings, or a pointer to either. Returns nil for nil pointers and
+// for other types.
+func stringValues(v reflect.Value) []string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return []string{v.String()}
+	case reflect.Slice, reflect.Array:
+		var values []string
+
+		for i := range v.Len() {
+			values = append(values, stringValues(v.Index(i))...)
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
+
+// updateStrings replaces the strings held by v, which is a string, a slice or
+// array of strings, or a pointer to either, with the result of fn.
+func updateStrings(v reflect.Value, fn func(string) string) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(fn(v.String()))
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			updateStrings(v.Index(i), fn)
+		}
+	}
+}
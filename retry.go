@@ -0,0 +1,91 @@
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// ReadConfigRetry is like ReadConfig, but retries reading the configuration
+// file up to the given number of attempts if it fails with a transient I/O
+// error, e.g. because a network file system briefly hiccups during boot. The
+// delay between attempts starts at backoff and doubles after every attempt.
+// Waiting stops once ctx is done, in which case the error of ctx is returned.
+//
+// Only reading is retried, and only if it fails with a known transient error:
+// EAGAIN, EINTR, EBUSY, or ESTALE on Unix, and sharing or lock violations on
+// Windows. Locating the file, unmarshaling, validation, and any other errors
+// are not retried, since they would fail the same way again. An attempts
+// value less than 1 is treated as 1. Standard input, as selected by
+// StdinPath, is read only once, since it cannot be read again.
+func ReadConfigRetry[T any](ctx context.Context, attempts int, backoff time.Duration, path string, searchPaths []string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) (string, error) {
+	var err error
+
+	o := newOptions(opts)
+
+	err = ctx.Err()
+	if err != nil {
+		return "", err
+	}
+
+	if path == StdinPath {
+		return stdinName, readConfig(stdinName, os.Stdin, c, unmarshal, finalize, o)
+	}
+
+	path, err = FindConfig(path, searchPaths, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := retryRead(ctx, attempts, backoff, path, o, readFoundContent)
+	if err != nil {
+		return path, err
+	}
+
+	return path, processConfig(path, content, c, unmarshal, finalize, o.forFile())
+}
+
+// retryRead reads the configuration file at path using read, retrying
+// transient errors as described for ReadConfigRetry. The errors returned by
+// read are expected to name the path already.
+func retryRead(ctx context.Context, attempts int, backoff time.Duration, path string, o *options, read func(string, *options) ([]byte, error)) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		content, err := read(path, o)
+		if err == nil {
+			return content, nil
+		}
+
+		if attempt >= attempts || !isTransient(err) {
+			return nil, err
+		}
+
+		o.debug("retrying configuration file", "path", path, "attempt", attempt, "error", err)
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+// isTransient reports whether err is an I/O error that may go away when
+// retrying, i.e. whether it is one of transientErrors. Deterministic
+// failures, such as a missing file, a directory, or a name that is too long,
+// are not retried.
+func isTransient(err error) bool {
+	for _, target := range transientErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,7 @@
+//go:build !unix && !windows
+
+package gonfig
+
+// transientErrors are the errors for which ReadConfigRetry retries reading.
+// There are none known on this platform.
+var transientErrors []error
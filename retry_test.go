@@ -0,0 +1,44 @@
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadConfigRetry(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "app"}`)
+
+	var c testConfig
+
+	_, err := ReadConfigRetry(context.Background(), 3, time.Millisecond, path, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" {
+		t.Errorf("got name %q, want app", c.Name)
+	}
+
+	_, err = ReadConfigRetry(context.Background(), 3, time.Hour, filepath.Join(t.TempDir(), "missing.json"), nil, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound without retrying", err)
+	}
+}
+
+func TestReadConfigRetryStdin(t *testing.T) {
+	replaceStdin(t, `{"Name": "stdin"}`)
+
+	var c testConfig
+
+	path, err := ReadConfigRetry(context.Background(), 3, time.Millisecond, StdinPath, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "<stdin>" || c.Name != "stdin" {
+		t.Errorf("got name %q from %s, want stdin from <stdin>", c.Name, path)
+	}
+}
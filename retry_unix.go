@@ -0,0 +1,8 @@
+//go:build unix
+
+package gonfig
+
+import "syscall"
+
+// transientErrors are the errors for which ReadConfigRetry retries reading.
+var transientErrors = []error{syscall.EAGAIN, syscall.EINTR, syscall.EBUSY, syscall.ESTALE}
@@ -0,0 +1,125 @@
+//go:build unix
+
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{syscall.EAGAIN, true},
+		{syscall.EINTR, true},
+		{syscall.EBUSY, true},
+		{syscall.ESTALE, true},
+		{syscall.EISDIR, false},
+		{syscall.ENAMETOOLONG, false},
+		{syscall.ENOENT, false},
+		{syscall.EACCES, false},
+	}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", &fs.PathError{Op: "read", Path: "config.json", Err: tt.err})
+
+		if got := isTransient(err); got != tt.want {
+			t.Errorf("isTransient(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// flakyRead returns a read function for retryRead that fails with err the
+// given number of times before returning content, counting its calls.
+func flakyRead(failures int, err error, content string, calls *int) func(string, *options) ([]byte, error) {
+	return func(path string, o *options) ([]byte, error) {
+		*calls++
+		if *calls <= failures {
+			return nil, &fs.PathError{Op: "read", Path: path, Err: err}
+		}
+
+		return []byte(content), nil
+	}
+}
+
+func TestRetryRead(t *testing.T) {
+	var calls int
+
+	content, err := retryRead(context.Background(), 3, time.Millisecond, "config.json", nil, flakyRead(2, syscall.EAGAIN, `{}`, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != `{}` || calls != 3 {
+		t.Errorf("got content %q after %d calls, want {} after 3", content, calls)
+	}
+}
+
+func TestRetryReadGivesUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		attempts  int
+		wantCalls int
+	}{
+		{"attempts exhausted", syscall.EAGAIN, 2, 2},
+		{"not transient", syscall.EISDIR, 3, 1},
+		{"attempts below 1", syscall.EAGAIN, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+
+			_, err := retryRead(context.Background(), tt.attempts, time.Millisecond, "config.json", nil, flakyRead(5, tt.err, `{}`, &calls))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryReadContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var calls int
+
+	_, err := retryRead(ctx, 3, time.Hour, "config.json", nil, flakyRead(5, syscall.EAGAIN, `{}`, &calls))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestReadConfigRetryChecksPerms(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "app"}`)
+
+	err := os.Chmod(path, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c testConfig
+
+	_, err = ReadConfigRetry(context.Background(), 3, time.Millisecond, path, nil, &c, JSON[testConfig](), nil, RequireSecurePerms())
+	if err == nil || !strings.Contains(err.Error(), "accessible by others") {
+		t.Errorf("got error %v, want a permission error", err)
+	}
+
+	if c.Name != "" {
+		t.Errorf("got name %q, want the configuration object untouched", c.Name)
+	}
+}
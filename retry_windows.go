@@ -0,0 +1,10 @@
+//go:build windows
+
+package gonfig
+
+import "golang.org/x/sys/windows"
+
+// transientErrors are the errors for which ReadConfigRetry retries reading,
+// which on Windows are caused by other processes holding the file open or
+// locked.
+var transientErrors = []error{windows.ERROR_SHARING_VIOLATION, windows.ERROR_LOCK_VIOLATION}
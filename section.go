@@ -0,0 +1,63 @@
+package gonfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ReadSection reads the configuration file at path, unmarshals the value of
+// its top-level key into the given configuration object, and validates it.
+// This lets a single file hold the configuration of multiple consumers under
+// different keys.
+//
+// The content is decoded using decode, e.g. json.Unmarshal, into a struct
+// whose only field holds the section and is tagged with key for the json,
+// toml, and yaml formats, so that the section is decoded according to the
+// struct tags of T. Returns an error naming the key if it is missing.
+func ReadSection[T any](path string, key string, c *T, decode func([]byte, any) error, finalize FinalizeFunc[*T], opts ...Option) error {
+	return ReadFoundConfig(path, c, sectionUnmarshal[T](key, decode), finalize, opts...)
+}
+
+// PeekField returns the value of the top-level key of the configuration file
+// at path, e.g. a version field, without unmarshaling the rest of the file
+// into a configuration object. This lets callers decide how to read the file
+// before reading it in full. The content is decoded using decode as described
+// for ReadSection. Returns an error naming the key if it is missing.
+func PeekField[T any](path string, key string, decode func([]byte, any) error, opts ...Option) (T, error) {
+	var v T
+
+	err := ReadFoundConfig(path, &v, sectionUnmarshal[T](key, decode), nil, opts...)
+
+	return v, err
+}
+
+// sectionUnmarshal returns an UnmarshalFunc that decodes the value of the
+// top-level key using decode.
+func sectionUnmarshal[T any](key string, decode func([]byte, any) error) UnmarshalFunc[*T] {
+	q := strconv.Quote(key)
+
+	wrapper := reflect.StructOf([]reflect.StructField{{
+		Name: "Section",
+		Type: reflect.TypeFor[*T](),
+		Tag:  reflect.StructTag("json:" + q + " toml:" + q + " yaml:" + q),
+	}})
+
+	return func(content []byte, c *T) error {
+		w := reflect.New(wrapper)
+
+		err := decode(content, w.Interface())
+		if err != nil {
+			return err
+		}
+
+		section := w.Elem().Field(0)
+		if section.IsNil() {
+			return fmt.Errorf("missing key %q", key)
+		}
+
+		*c = *section.Interface().(*T)
+
+		return nil
+	}
+}
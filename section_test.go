@@ -0,0 +1,85 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReadSection(t *testing.T) {
+	type lint struct {
+		Rules []string `json:"rules"`
+	}
+
+	type format struct {
+		Width int `json:"width"`
+	}
+
+	path := writeFile(t, t.TempDir(), "tools.json", `{
+		"lint": {"rules": ["a", "b"]},
+		"format": {"width": 100}
+	}`)
+
+	var l lint
+
+	err := ReadSection(path, "lint", &l, json.Unmarshal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.Rules) != 2 || l.Rules[0] != "a" || l.Rules[1] != "b" {
+		t.Errorf("got lint %+v", l)
+	}
+
+	var f format
+
+	err = ReadSection(path, "format", &f, json.Unmarshal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Width != 100 {
+		t.Errorf("got width %d, want 100", f.Width)
+	}
+
+	err = ReadSection(path, "test", &f, json.Unmarshal, nil)
+	if err == nil || !strings.Contains(err.Error(), `missing key "test"`) {
+		t.Errorf("got error %v, want one naming the missing key", err)
+	}
+}
+
+func TestPeekField(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{
+		"format": "v2",
+		"version": 3,
+		"servers": [{"host": "a"}, {"host": "b"}]
+	}`)
+
+	format, err := PeekField[string](path, "format", json.Unmarshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if format != "v2" {
+		t.Errorf("got format %q, want v2", format)
+	}
+
+	version, err := PeekField[int](path, "version", json.Unmarshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != 3 {
+		t.Errorf("got version %d, want 3", version)
+	}
+
+	_, err = PeekField[int](path, "format", json.Unmarshal)
+	if err == nil {
+		t.Error("got no error for a mismatching type")
+	}
+
+	_, err = PeekField[string](path, "missing", json.Unmarshal)
+	if err == nil || !strings.Contains(err.Error(), `missing key "missing"`) {
+		t.Errorf("got error %v, want one naming the missing key", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package gonfig
+
+import "os"
+
+// reloadSignals are the signals ReloadOnSignal listens for by default. There
+// is no conventional reload signal on platforms other than Unix.
+var reloadSignals []os.Signal
@@ -0,0 +1,19 @@
+//go:build !unix
+
+package gonfig
+
+import "testing"
+
+func TestReloadOnSignalNoDefault(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":"a"}`)
+
+	l, err := NewLoader(path, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := ReloadOnSignal(l, func(err error) {
+		t.Errorf("got reload with error %v without signals", err)
+	})
+	stop()
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package gonfig
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals ReloadOnSignal listens for by default.
+var reloadSignals = []os.Signal{syscall.SIGHUP}
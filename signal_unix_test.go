@@ -0,0 +1,100 @@
+//go:build unix
+
+package gonfig
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloadOnSignalDefault(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name":"a"}`)
+
+	l, err := NewLoader(path, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := make(chan error, 1)
+
+	stop := ReloadOnSignal(l, func(err error) { reloaded <- err })
+	defer stop()
+
+	writeFile(t, filepath.Dir(path), "config.json", `{"Name":"b"}`)
+
+	err = syscall.Kill(syscall.Getpid(), syscall.SIGHUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-reloaded:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload after SIGHUP")
+	}
+
+	if got := l.Current().Name; got != "b" {
+		t.Errorf("got name %q, want b", got)
+	}
+}
+
+func TestReloadOnSignalStop(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name":"a"}`)
+
+	l, err := NewLoader(path, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keep the process from terminating on SIGUSR1 once stop unregisters
+	// the handler under test.
+	ignore := make(chan os.Signal, 1)
+	signal.Notify(ignore, syscall.SIGUSR1)
+	defer signal.Stop(ignore)
+
+	reloaded := make(chan error, 1)
+
+	stop := ReloadOnSignal(l, func(err error) { reloaded <- err }, syscall.SIGUSR1)
+
+	writeFile(t, dir, "config.json", `{`)
+
+	err = syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-reloaded:
+		if err == nil {
+			t.Error("got no error reloading malformed content")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload after SIGUSR1")
+	}
+
+	if got := l.Current().Name; got != "a" {
+		t.Errorf("got name %q after a failed reload, want a", got)
+	}
+
+	stop()
+	stop()
+
+	err = syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-reloaded:
+		t.Errorf("got reload with error %v after stop", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,60 @@
+package gonfig
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// ReadConfigSocket reads a configuration from the Unix domain socket at
+// socketPath, e.g. served by a local agent, unmarshals it into the given
+// configuration object, and validates it.
+//
+// If request is not nil, it is written to the socket first, after which the
+// writing side of the connection is shut down to signal the end of the
+// request. The response is read until the peer closes the connection.
+// Connecting, writing, and reading are bound to ctx.
+func ReadConfigSocket[T any](ctx context.Context, socketPath string, request []byte, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	o := newOptions(opts)
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("unable to connect to configuration socket %s: %w", socketPath, err)
+	}
+	defer conn.Close()
+
+	// Interrupt blocked reads and writes once ctx is done.
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	if request != nil {
+		_, err = conn.Write(request)
+		if err == nil {
+			err = conn.(*net.UnixConn).CloseWrite()
+		}
+		if err != nil {
+			return fmt.Errorf("unable to send request to configuration socket %s: %w", socketPath, socketErr(ctx, err))
+		}
+	}
+
+	content, err := readAll(conn, o)
+	if err != nil {
+		return fmt.Errorf("unable to read from configuration socket %s: %w", socketPath, socketErr(ctx, err))
+	}
+
+	return processConfig(socketPath, content, c, unmarshal, finalize, o)
+}
+
+// socketErr returns the error of ctx if it is done, since err is then caused
+// by closing the connection, and err otherwise.
+func socketErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
@@ -0,0 +1,150 @@
+//go:build unix
+
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// serveSocket listens on a temporary Unix domain socket, passes every
+// connection to handle, and returns the socket path.
+func serveSocket(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	// Socket paths are limited to about 100 bytes, which t.TempDir may
+	// exceed.
+	dir, err := os.MkdirTemp("", "gonfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "config.sock")
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+
+	return path
+}
+
+func TestReadConfigSocket(t *testing.T) {
+	path := serveSocket(t, func(conn net.Conn) {
+		conn.Write([]byte(`{"Name": "socket", "Port": 8080}`))
+	})
+
+	var c testConfig
+
+	err := ReadConfigSocket(context.Background(), path, nil, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (testConfig{Name: "socket", Port: 8080}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
+
+func TestReadConfigSocketLocalOverride(t *testing.T) {
+	path := serveSocket(t, func(conn net.Conn) {
+		conn.Write([]byte(`{"Name": "socket", "Port": 8080}`))
+	})
+
+	writeFile(t, filepath.Dir(path), "config.local.sock", `{"Port": 9090}`)
+
+	var c testConfig
+
+	err := ReadConfigSocket(context.Background(), path, nil, &c, JSON[testConfig](), nil, WithLocalOverride())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (testConfig{Name: "socket", Port: 8080}); c != want {
+		t.Errorf("got %+v, want %+v without a local override", c, want)
+	}
+}
+
+func TestReadConfigSocketRequest(t *testing.T) {
+	path := serveSocket(t, func(conn net.Conn) {
+		request, err := io.ReadAll(conn)
+		if err != nil {
+			return
+		}
+
+		conn.Write([]byte(`{"Name": "` + string(request) + `"}`))
+	})
+
+	var c testConfig
+
+	err := ReadConfigSocket(context.Background(), path, []byte("app"), &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" {
+		t.Errorf("got name %q, want the response to the request", c.Name)
+	}
+}
+
+func TestReadConfigSocketErrors(t *testing.T) {
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	slow := serveSocket(t, func(net.Conn) { <-block })
+	malformed := serveSocket(t, func(conn net.Conn) { conn.Write([]byte(`{`)) })
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := ReadConfigSocket(ctx, slow, nil, &testConfig{}, JSON[testConfig](), nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		c := testConfig{Name: "initial"}
+
+		err := ReadConfigSocket(context.Background(), malformed, nil, &c, JSON[testConfig](), nil)
+
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) {
+			t.Errorf("got error %v, want an *UnmarshalError", err)
+		}
+
+		if c.Name != "initial" {
+			t.Errorf("got name %q, want initial", c.Name)
+		}
+	})
+
+	t.Run("missing socket", func(t *testing.T) {
+		err := ReadConfigSocket(context.Background(), filepath.Join(t.TempDir(), "missing.sock"), nil, &testConfig{}, JSON[testConfig](), nil)
+		if err == nil {
+			t.Error("got no error for a missing socket")
+		}
+	})
+}
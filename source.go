@@ -0,0 +1,58 @@
+package gonfig
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConfigSource provides the raw content of a configuration, e.g. from a key
+// in a key-value store such as etcd or Consul. It is read by
+// ReadConfigSource, which leaves the choice of backend to the caller.
+// FileSource implements it for configuration files.
+//
+// The interface is not named Source, since Source already describes where a
+// configuration file was found.
+type ConfigSource interface {
+	// Read returns the content of the configuration. It should stop
+	// waiting for the backend once ctx is done.
+	Read(ctx context.Context) ([]byte, error)
+}
+
+// ReadConfigSource reads a configuration from src, unmarshals its content
+// into the given configuration object, and validates it as ReadConfig does
+// for configuration files. Returns an error if the content cannot be read,
+// unmarshaled, or validated.
+func ReadConfigSource[T any](ctx context.Context, src ConfigSource, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	content, err := src.Read(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration: %w", err)
+	}
+
+	return processConfig("", content, c, unmarshal, finalize, newOptions(opts))
+}
+
+// FileSource is a ConfigSource reading a configuration file, which is located
+// as in FindConfig using the primary path Path, the fallback paths
+// SearchPaths, and Options.
+type FileSource struct {
+	Path        string
+	SearchPaths []string
+	Options     []Option
+}
+
+// Read locates and reads the configuration file, checking its permissions as
+// requested by Options like ReadFoundConfig does. As with ReadConfigContext,
+// the underlying read cannot be interrupted once ctx is done and keeps
+// running in the background until it returns on its own.
+func (s FileSource) Read(ctx context.Context) ([]byte, error) {
+	path, err := FindConfig(s.Path, s.SearchPaths, s.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newOptions(s.Options)
+
+	return readContext(ctx, func() ([]byte, error) {
+		return readFoundContent(path, o)
+	})
+}
@@ -0,0 +1,82 @@
+package gonfig
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+type memorySource []byte
+
+func (s memorySource) Read(context.Context) ([]byte, error) {
+	return s, nil
+}
+
+type failingSource struct {
+	err error
+}
+
+func (s failingSource) Read(context.Context) ([]byte, error) {
+	return nil, s.err
+}
+
+func TestReadConfigSource(t *testing.T) {
+	var c testConfig
+
+	err := ReadConfigSource(context.Background(), memorySource(`{"Name":"kv","Port":8080}`), &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (testConfig{Name: "kv", Port: 8080}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+
+	errInvalid := errors.New("invalid")
+
+	err = ReadConfigSource(context.Background(), memorySource(`{"Name":"kv"}`), &c, JSON[testConfig](), func(*testConfig) error {
+		return errInvalid
+	})
+	if !errors.Is(err, errInvalid) {
+		t.Errorf("got error %v, want %v", err, errInvalid)
+	}
+}
+
+func TestReadConfigSourceFailure(t *testing.T) {
+	errUnavailable := errors.New("backend unavailable")
+
+	c := testConfig{Name: "initial"}
+
+	err := ReadConfigSource(context.Background(), failingSource{errUnavailable}, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("got error %v, want %v", err, errUnavailable)
+	}
+
+	if c.Name != "initial" {
+		t.Errorf("got name %q, want initial", c.Name)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name":"file"}`)
+
+	var c testConfig
+
+	src := FileSource{SearchPaths: []string{filepath.Join(dir, "missing.json"), path}}
+
+	err := ReadConfigSource(context.Background(), src, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "file" {
+		t.Errorf("got name %q, want file", c.Name)
+	}
+
+	err = ReadConfigSource(context.Background(), FileSource{SearchPaths: []string{filepath.Join(dir, "missing.json")}}, &c, JSON[testConfig](), nil)
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Errorf("got error %v, want ErrConfigNotFound", err)
+	}
+}
@@ -0,0 +1,33 @@
+package gonfig
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// WarnIfStale compares the modification time of the configuration file at
+// path with that of the reference file at reference, e.g. a schema file
+// shipped with the application, and passes an error describing both times to
+// warn if the configuration file is older, i.e. it may predate a change of
+// the schema. This is a lightweight staleness check that leaves it to the
+// caller whether to proceed.
+//
+// Returns an error if either file cannot be stat'ed.
+func WarnIfStale(path, reference string, warn func(error)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not stat configuration file %s: %w", path, err)
+	}
+
+	ref, err := os.Stat(reference)
+	if err != nil {
+		return fmt.Errorf("could not stat reference file %s: %w", reference, err)
+	}
+
+	if info.ModTime().Before(ref.ModTime()) {
+		warn(fmt.Errorf("configuration file %s (modified %s) is older than %s (modified %s)", path, info.ModTime().Format(time.RFC3339), reference, ref.ModTime().Format(time.RFC3339)))
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package gonfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWarnIfStale(t *testing.T) {
+	dir := t.TempDir()
+	schema := writeFile(t, dir, "schema.json", `{}`)
+
+	now := time.Now()
+
+	err := os.Chtimes(schema, now, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		modified time.Time
+		wantWarn bool
+	}{
+		{"older", now.Add(-time.Hour), true},
+		{"newer", now.Add(time.Hour), false},
+		{"same", now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, "config.json", `{}`)
+
+			err := os.Chtimes(path, tt.modified, tt.modified)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var warnings []error
+
+			err = WarnIfStale(path, schema, func(err error) { warnings = append(warnings, err) })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.wantWarn {
+				if len(warnings) != 0 {
+					t.Errorf("got warnings %v, want none", warnings)
+				}
+
+				return
+			}
+
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "is older than "+schema) {
+				t.Errorf("got warnings %v, want one about the configuration being older", warnings)
+			}
+		})
+	}
+}
+
+func TestWarnIfStaleMissing(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{}`)
+	missing := filepath.Join(dir, "missing.json")
+
+	warn := func(err error) { t.Errorf("got warning %v, want none", err) }
+
+	for _, paths := range [][2]string{{missing, path}, {path, missing}} {
+		err := WarnIfStale(paths[0], paths[1], warn)
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("got error %v, want fs.ErrNotExist", err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package gonfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Decoder decodes consecutive documents from a stream, returning io.EOF once
+// the stream is exhausted, like *json.Decoder and *yaml.Decoder.
+type Decoder interface {
+	Decode(v any) error
+}
+
+// NewDecoderFunc is a function that returns a Decoder reading from r.
+type NewDecoderFunc func(r io.Reader) Decoder
+
+// ReadConfigAll reads a configuration file holding a stream of documents,
+// e.g. a YAML file with documents separated by "---" or a JSON Lines file,
+// and returns one configuration object per document, each unmarshaled using
+// the Decoder returned by newDecoder and validated. This suits configuration
+// files that are lists of records, e.g. of jobs.
+//
+// A file with a single document yields a one-element slice. Unmarshaling
+// errors are reported as *UnmarshalError, validation errors are prefixed with
+// the 1-based index of the offending document.
+//
+// The file is read and its content prepared as for ReadFoundConfig, so that
+// the permission checks, WithDecrypt, and RequireUTF8 apply, while
+// AllowExec and WarnOnInvalid apply to each document. With AllowEmpty, an
+// empty file yields a single configuration object holding the default
+// values; otherwise it yields no documents. WithLocalOverride does not apply.
+func ReadConfigAll[T any](path string, newDecoder NewDecoderFunc, finalize FinalizeFunc[*T], opts ...Option) ([]T, error) {
+	o := newOptions(opts)
+
+	content, err := readFoundContent(path, o)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err = prepareContent(path, content, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.allowEmpty && len(bytes.TrimSpace(content)) == 0 {
+		var c T
+
+		err = ApplyDefaults(&c)
+		if err != nil {
+			return nil, err
+		}
+
+		err = finishConfig(&c, finalize, o)
+		if err != nil {
+			return nil, fmt.Errorf("document 1 of configuration file %s: %w", path, err)
+		}
+
+		return []T{c}, nil
+	}
+
+	var all []T
+
+	dec := newDecoder(bytes.NewReader(content))
+
+	for {
+		var c T
+
+		err = dec.Decode(&c)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			line, column := position(err, content)
+
+			return nil, &UnmarshalError{Path: path, Content: content, Line: line, Column: column, Err: err}
+		}
+
+		err = finishConfig(&c, finalize, o)
+		if err != nil {
+			return nil, fmt.Errorf("document %d of configuration file %s: %w", len(all)+1, path, err)
+		}
+
+		all = append(all, c)
+	}
+
+	return all, nil
+}
+
+// ReadConfigStream reads the JSON configuration file at path incrementally by
+// passing a *json.Decoder reading from the file to decode, instead of reading
+// the whole file into memory first. This is meant for very large
+// configuration files, e.g. with thousands of entries, which decode can
+// process one at a time using the Token and More methods of the decoder.
+//
+// The maximum size set using WithMaxSize still applies, but is only detected
+// once it is exceeded during decoding. Compressed files are not supported.
+// Returns an error if the file cannot be opened or if decode fails.
+func ReadConfigStream(path string, decode func(*json.Decoder) error, opts ...Option) error {
+	o := newOptions(opts)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if limit := o.limit(); limit > 0 {
+		r = &limitedReader{r: f, n: limit}
+	}
+
+	err = decode(json.NewDecoder(r))
+	if err != nil {
+		return fmt.Errorf("unable to decode configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// limitedReader reads from r, failing once more than n bytes have been read.
+type limitedReader struct {
+	r    io.Reader
+	n    int64
+	read int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+
+	if l.read > l.n {
+		return 0, fmt.Errorf("content exceeds the maximum size of %d bytes", l.n)
+	}
+
+	return n, err
+}
@@ -0,0 +1,243 @@
+package gonfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigAllJSONLines(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "jobs.jsonl", `{"Name": "a", "Port": 1}
+{"Name": "b", "Port": 2}
+{"Name": "c", "Port": 3}
+`)
+
+	all, err := ReadConfigAll[testConfig](path, JSONDecoder(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []testConfig{{"a", 1}, {"b", 2}, {"c", 3}}
+	if len(all) != len(want) {
+		t.Fatalf("got %d documents, want %d", len(all), len(want))
+	}
+
+	for i := range want {
+		if all[i] != want[i] {
+			t.Errorf("got document %d %+v, want %+v", i, all[i], want[i])
+		}
+	}
+}
+
+func TestReadConfigAllSingle(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "a"}`)
+
+	all, err := ReadConfigAll[testConfig](path, JSONDecoder(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 1 || all[0].Name != "a" {
+		t.Errorf("got %+v, want a single document", all)
+	}
+}
+
+func TestReadConfigAllErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	path := writeFile(t, dir, "invalid.jsonl", "{\"Name\": \"a\", \"Port\": 1}\n{\"Name\": \"b\"}\n")
+
+	_, err := ReadConfigAll(path, JSONDecoder(), func(c *testConfig) error {
+		if c.Port == 0 {
+			return errors.New("port is required")
+		}
+
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "document 2") {
+		t.Errorf("got error %v, want it to name document 2", err)
+	}
+
+	path = writeFile(t, dir, "malformed.jsonl", "{\"Name\": \"a\"}\n{\n")
+
+	_, err = ReadConfigAll[testConfig](path, JSONDecoder(), nil)
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Errorf("got error %v, want an *UnmarshalError", err)
+	}
+}
+
+func TestReadConfigAllOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	detect := func(content []byte) bool { return bytes.HasPrefix(content, xorMagic) }
+
+	path := writeFile(t, dir, "encrypted.jsonl", string(xorEncrypt([]byte("{\"Name\": \"a\"}\n{\"Name\": \"b\"}\n"))))
+
+	all, err := ReadConfigAll[testConfig](path, JSONDecoder(), nil, WithDecrypt(xorDecrypt, detect))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 2 || all[0].Name != "a" || all[1].Name != "b" {
+		t.Errorf("got %+v, want the decrypted documents", all)
+	}
+
+	path = writeFile(t, dir, "invalid.jsonl", "{\"Name\": \"\xff\"}\n")
+
+	_, err = ReadConfigAll[testConfig](path, JSONDecoder(), nil, RequireUTF8())
+	if err == nil || !strings.Contains(err.Error(), "not valid UTF-8") {
+		t.Errorf("got error %v, want a UTF-8 error", err)
+	}
+
+	type config struct {
+		Name string `default:"app"`
+	}
+
+	path = writeFile(t, dir, "empty.jsonl", "\n")
+
+	defaults, err := ReadConfigAll[config](path, JSONDecoder(), nil, AllowEmpty())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(defaults) != 1 || defaults[0].Name != "app" {
+		t.Errorf("got %+v, want a single document holding the defaults", defaults)
+	}
+}
+
+// writeEntries writes a JSON array of n configuration objects to a file in dir
+// and returns its path.
+func writeEntries(tb testing.TB, dir string, n int) string {
+	tb.Helper()
+
+	var b strings.Builder
+
+	b.WriteString("[\n")
+
+	for i := range n {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+
+		fmt.Fprintf(&b, `  {"Name": "entry-%d", "Port": %d}`, i, i)
+	}
+
+	b.WriteString("\n]\n")
+
+	path := filepath.Join(dir, "entries.json")
+
+	err := os.WriteFile(path, []byte(b.String()), 0o644)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return path
+}
+
+// decodeEntries returns a decode function for ReadConfigStream that decodes
+// the elements of a JSON array one at a time, calling fn for each.
+func decodeEntries(fn func(testConfig)) func(*json.Decoder) error {
+	return func(dec *json.Decoder) error {
+		_, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		for dec.More() {
+			var c testConfig
+
+			err = dec.Decode(&c)
+			if err != nil {
+				return err
+			}
+
+			fn(c)
+		}
+
+		_, err = dec.Token()
+
+		return err
+	}
+}
+
+func TestReadConfigStream(t *testing.T) {
+	const n = 10000
+
+	path := writeEntries(t, t.TempDir(), n)
+
+	count, sum := 0, 0
+
+	err := ReadConfigStream(path, decodeEntries(func(c testConfig) {
+		if c.Name != fmt.Sprintf("entry-%d", count) {
+			t.Errorf("got name %q for entry %d", c.Name, count)
+		}
+
+		count++
+		sum += c.Port
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != n || sum != n*(n-1)/2 {
+		t.Errorf("got %d entries with port sum %d, want %d with %d", count, sum, n, n*(n-1)/2)
+	}
+}
+
+func TestReadConfigStreamErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := writeEntries(t, dir, 100)
+
+	err := ReadConfigStream(path, decodeEntries(func(testConfig) {}), WithMaxSize(64))
+	if err == nil || !strings.Contains(err.Error(), "maximum size") {
+		t.Errorf("got error %v, want the maximum size to be exceeded", err)
+	}
+
+	errDecode := errors.New("decode failed")
+
+	err = ReadConfigStream(path, func(*json.Decoder) error { return errDecode })
+	if !errors.Is(err, errDecode) || !strings.Contains(err.Error(), path) {
+		t.Errorf("got error %v, want it to wrap errDecode with the path", err)
+	}
+
+	err = ReadConfigStream(filepath.Join(dir, "missing.json"), decodeEntries(func(testConfig) {}))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v, want it to match os.ErrNotExist", err)
+	}
+}
+
+func BenchmarkReadConfigStream(b *testing.B) {
+	path := writeEntries(b, b.TempDir(), 10000)
+
+	b.Run("ReadConfigStream", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			err := ReadConfigStream(path, decodeEntries(func(testConfig) {}))
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// ReadFoundConfig buffers the whole file and holds all entries at once.
+	b.Run("ReadFoundConfig", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			var entries []testConfig
+
+			err := ReadFoundConfig(path, &entries, JSON[[]testConfig](), nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
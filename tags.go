@@ -0,0 +1,43 @@
+package gonfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagOptions holds the options of a gonfig struct tag, which is a
+// comma-separated list of flags, e.g. required, and key-value pairs, e.g.
+// min=1. Since regular expressions may contain commas, a pattern option
+// extends to the end of the tag and must therefore come last.
+type tagOptions map[string]string
+
+// parseTag parses the gonfig struct tag of sf.
+func parseTag(sf reflect.StructField) tagOptions {
+	tag := sf.Tag.Get("gonfig")
+	if tag == "" {
+		return nil
+	}
+
+	opts := tagOptions{}
+
+	for tag != "" {
+		opt, rest, _ := strings.Cut(tag, ",")
+
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		if key == "pattern" {
+			_, value, _ = strings.Cut(tag, "=")
+			rest = ""
+		}
+
+		opts[key] = value
+		tag = rest
+	}
+
+	return opts
+}
+
+// has reports whether the option key is present.
+func (o tagOptions) has(key string) bool {
+	_, ok := o[key]
+	return ok
+}
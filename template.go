@@ -0,0 +1,17 @@
+package gonfig
+
+// GenerateTemplate returns the content of a configuration file for
+// bootstrapping users, e.g. for an init command. It applies the default
+// struct tags to the configuration object using ApplyDefaults and marshals
+// the result, so that c can be a zero value or a partially populated one.
+//
+// Combined with MarshalTOML, comment struct tags are emitted as comments,
+// yielding a self-documenting file.
+func GenerateTemplate[T any](c *T, marshal MarshalFunc[*T]) ([]byte, error) {
+	err := ApplyDefaults(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(c)
+}
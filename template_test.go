@@ -0,0 +1,28 @@
+package gonfig
+
+import "testing"
+
+func TestGenerateTemplate(t *testing.T) {
+	type config struct {
+		Name string `default:"app"`
+		Port int    `default:"8080"`
+	}
+
+	var c config
+
+	content, err := GenerateTemplate(&c, MarshalJSON[config]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got config
+
+	err = ReadConfigBytes(content, &got, JSON[config](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (config{Name: "app", Port: 8080}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package gonfig
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// TLSKeyPair returns a FinalizeFunc that checks whether the certificate and
+// key files whose paths are returned by cert and key load as a key pair using
+// tls.LoadX509KeyPair, so that a missing file or a mismatched pair fails at
+// startup instead of on the first connection. Since this reads files, it must
+// be opted into explicitly. The check is skipped if both paths are empty,
+// e.g. because TLS is disabled.
+func TLSKeyPair[T any](cert, key func(T) string) FinalizeFunc[T] {
+	return func(c T) error {
+		certFile, keyFile := cert(c), key(c)
+		if certFile == "" && keyFile == "" {
+			return nil
+		}
+
+		_, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load TLS key pair %s and %s: %w", certFile, keyFile, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package gonfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeKeyPair generates a self-signed certificate and its key, writes them
+// to name.pem and name.key in dir, and returns their paths.
+func writeKeyPair(t *testing.T, dir, name string) (cert, key string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert = writeFile(t, dir, name+".pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	key = writeFile(t, dir, name+".key", string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+
+	return cert, key
+}
+
+func TestTLSKeyPair(t *testing.T) {
+	type config struct {
+		CertFile string
+		KeyFile  string
+	}
+
+	dir := t.TempDir()
+	cert, key := writeKeyPair(t, dir, "a")
+	_, otherKey := writeKeyPair(t, dir, "b")
+
+	validate := TLSKeyPair(func(c *config) string { return c.CertFile }, func(c *config) string { return c.KeyFile })
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr bool
+	}{
+		{"valid pair", config{CertFile: cert, KeyFile: key}, false},
+		{"disabled", config{}, false},
+		{"mismatched pair", config{CertFile: cert, KeyFile: otherKey}, true},
+		{"missing key", config{CertFile: cert, KeyFile: filepath.Join(dir, "missing.key")}, true},
+		{"key only", config{KeyFile: key}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "unable to load TLS key pair") {
+				t.Errorf("got error %v, want a key pair error", err)
+			}
+		})
+	}
+}
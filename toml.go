@@ -0,0 +1,352 @@
+//go:build gonfig_toml
+
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+func init() {
+	RegisterDecoder(".toml", toml.Unmarshal)
+	positionFuncs = append(positionFuncs, tomlPosition)
+}
+
+// tomlPosition extracts the position from TOML parse errors.
+func tomlPosition(err error, _ []byte) (int, int, bool) {
+	var parseErr toml.ParseError
+	if !errors.As(err, &parseErr) {
+		return 0, 0, false
+	}
+
+	return parseErr.Position.Line, parseErr.Position.Col, true
+}
+
+// TOML returns an UnmarshalFunc that decodes TOML content.
+//
+// Only available with the gonfig_toml build tag, which pulls in
+// github.com/BurntSushi/toml.
+func TOML[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		return toml.Unmarshal(content, c)
+	}
+}
+
+// TOMLStrict returns an UnmarshalFunc that decodes TOML content and rejects
+// keys that do not correspond to a field of the configuration object. The
+// error lists all unexpected keys.
+//
+// Only available with the gonfig_toml build tag.
+func TOMLStrict[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		md, err := toml.Decode(string(content), c)
+		if err != nil {
+			return err
+		}
+
+		undecoded := md.Undecoded()
+		if len(undecoded) == 0 {
+			return nil
+		}
+
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = strconv.Quote(key.String())
+		}
+
+		return fmt.Errorf("toml: unknown keys %s", strings.Join(keys, ", "))
+	}
+}
+
+// MarshalTOML returns a MarshalFunc that encodes the configuration object as
+// TOML. The content of comment struct tags, e.g. `comment:"Port to listen
+// on."`, is emitted as inline comments next to the corresponding keys and
+// tables.
+//
+// Only available with the gonfig_toml build tag.
+func MarshalTOML[T any]() MarshalFunc[*T] {
+	return func(c *T) ([]byte, error) {
+		content, err := toml.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+
+		comments := map[string]string{}
+		collectTOMLComments(reflect.TypeFor[T](), "", comments)
+
+		if len(comments) == 0 {
+			return content, nil
+		}
+
+		return addTOMLComments(content, comments), nil
+	}
+}
+
+// collectTOMLComments collects the comment struct tags of the fields of t,
+// keyed by their dotted TOML key.
+func collectTOMLComments(t reflect.Type, prefix string, comments map[string]string) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	if !isStruct(t) {
+		return
+	}
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		comment, ok := sf.Tag.Lookup("comment")
+		if ok {
+			comments[key] = strings.ReplaceAll(comment, "\n", " ")
+		}
+
+		collectTOMLComments(sf.Type, key, comments)
+	}
+}
+
+// addTOMLComments appends the comments as inline comments to the lines of the
+// TOML content defining the corresponding keys and tables.
+func addTOMLComments(content []byte, comments map[string]string) []byte {
+	lines := strings.Split(string(content), "\n")
+	table := ""
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		var key string
+
+		switch {
+		case strings.HasPrefix(trimmed, "["):
+			table = strings.Trim(trimmed, "[] ")
+			key = table
+		case strings.Contains(trimmed, " = "):
+			name, _, _ := strings.Cut(trimmed, " = ")
+
+			unquoted, err := strconv.Unquote(name)
+			if err == nil {
+				name = unquoted
+			}
+
+			key = name
+			if table != "" {
+				key = table + "." + name
+			}
+		default:
+			continue
+		}
+
+		comment, ok := comments[key]
+		if ok {
+			lines[i] = line + " # " + comment
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// ReadTOMLWithComments reads the TOML configuration file at path like
+// ReadFoundConfig using TOML, and additionally returns the comments of the
+// file keyed by the dotted keys of the tables and key-value pairs they
+// belong to, e.g. for a configuration editor echoing them back.
+//
+// A comment belongs to the following table or key if it is on the lines
+// directly above it, or to the preceding table or key if it is on the same
+// line. Multiple comment lines are joined using newlines, with the leading
+// "#" and surrounding white space removed.
+//
+// Only available with the gonfig_toml build tag.
+func ReadTOMLWithComments[T any](path string, c *T, finalize FinalizeFunc[*T], opts ...Option) (map[string]string, error) {
+	o := newOptions(opts)
+
+	content, err := readFoundContent(path, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// The comments are extracted from the same plain content that is
+	// unmarshaled, i.e. after decompression and decryption.
+	content, err = prepareContent(path, content, o)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unmarshalConfig(path, content, c, TOML[T](), finalize, o.forFile())
+	if err != nil {
+		return nil, err
+	}
+
+	return tomlComments(content), nil
+}
+
+// tomlComments extracts the comments of the TOML content, keyed by the dotted
+// keys they belong to.
+func tomlComments(content []byte) map[string]string {
+	comments := map[string]string{}
+
+	var (
+		table   string
+		pending []string
+		// multiline holds the delimiter of the multi-line string being
+		// skipped, if any.
+		multiline string
+	)
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if multiline != "" {
+			if strings.Contains(line, multiline) {
+				multiline = ""
+			}
+
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			pending = nil
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			pending = append(pending, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+			continue
+		}
+
+		code, inline := splitTOMLComment(trimmed)
+
+		var key string
+
+		if strings.HasPrefix(code, "[") {
+			table = tomlKey(strings.Trim(code, "[] "))
+			key = table
+		} else {
+			name, value, ok := strings.Cut(code, "=")
+			if !ok {
+				pending = nil
+				continue
+			}
+
+			key = tomlKey(name)
+			if table != "" {
+				key = table + "." + key
+			}
+
+			for _, delim := range []string{`"""`, `'''`} {
+				if strings.Count(value, delim) == 1 {
+					multiline = delim
+				}
+			}
+		}
+
+		if inline != "" {
+			pending = append(pending, inline)
+		}
+
+		if len(pending) > 0 {
+			comments[key] = strings.Join(pending, "\n")
+		}
+
+		pending = nil
+	}
+
+	return comments
+}
+
+// splitTOMLComment splits a line of TOML into the code and the content of the
+// comment following it, if any, ignoring "#" inside strings.
+func splitTOMLComment(line string) (string, string) {
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case quote == '"' && line[i] == '\\':
+			i++
+		case quote != 0:
+			if line[i] == quote {
+				quote = 0
+			}
+		case line[i] == '"' || line[i] == '\'':
+			quote = line[i]
+		case line[i] == '#':
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		}
+	}
+
+	return line, ""
+}
+
+// tomlKey returns the dotted form of the TOML key, with white space around
+// its parts removed and quoted parts unquoted.
+func tomlKey(key string) string {
+	var (
+		parts []string
+		part  strings.Builder
+		quote byte
+	)
+
+	for i := 0; i < len(key); i++ {
+		b := key[i]
+
+		switch {
+		case quote == '"' && b == '\\' && i+1 < len(key):
+			part.WriteByte(b)
+			i++
+			part.WriteByte(key[i])
+			continue
+		case quote != 0:
+			if b == quote {
+				quote = 0
+			}
+		case b == '"' || b == '\'':
+			quote = b
+		case b == '.':
+			parts = append(parts, unquoteTOMLKey(part.String()))
+			part.Reset()
+			continue
+		}
+
+		part.WriteByte(b)
+	}
+
+	parts = append(parts, unquoteTOMLKey(part.String()))
+
+	return strings.Join(parts, ".")
+}
+
+// unquoteTOMLKey removes white space around a part of a TOML key and unquotes
+// it if quoted.
+func unquoteTOMLKey(part string) string {
+	part = strings.TrimSpace(part)
+
+	if len(part) >= 2 && part[0] == '\'' && part[len(part)-1] == '\'' {
+		return part[1 : len(part)-1]
+	}
+
+	unquoted, err := strconv.Unquote(part)
+	if err == nil {
+		return unquoted
+	}
+
+	return part
+}
@@ -0,0 +1,277 @@
+//go:build gonfig_toml
+
+package gonfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tomlConfig struct {
+	Name    string        `toml:"name"`
+	Timeout time.Duration `toml:"timeout"`
+	Server  struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	} `toml:"server"`
+	Tags []string `toml:"tags"`
+}
+
+func TestTOML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.toml", `name = "app"
+timeout = "5s"
+tags = ["a", "b"]
+
+[server]
+host = "localhost"
+port = 8080
+`)
+
+	var c tomlConfig
+
+	_, err := ReadConfig(path, nil, &c, TOML[tomlConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" || c.Timeout != 5*time.Second || c.Server.Host != "localhost" || c.Server.Port != 8080 {
+		t.Errorf("got %+v", c)
+	}
+
+	if !reflect.DeepEqual(c.Tags, []string{"a", "b"}) {
+		t.Errorf("got tags %q, want [a b]", c.Tags)
+	}
+}
+
+func TestTOMLMalformed(t *testing.T) {
+	var c tomlConfig
+
+	err := TOML[tomlConfig]()([]byte("name = \n"), &c)
+	if err == nil {
+		t.Error("got no error for malformed TOML")
+	}
+}
+
+func TestReadTOMLWithComments(t *testing.T) {
+	type config struct {
+		Name        string `toml:"name"`
+		Description string `toml:"description"`
+		Server      struct {
+			Host string `toml:"host"`
+			Port int    `toml:"port"`
+		} `toml:"server"`
+	}
+
+	path := writeFile(t, t.TempDir(), "config.toml", `# The name of the application.
+# Shown in logs.
+name = "app # not a comment"
+
+# Detached from the following key.
+
+description = """
+# not a comment either
+"""
+
+# The server settings.
+[server]
+host = "localhost" # The host to bind.
+"port" = 8080
+`)
+
+	var c config
+
+	comments, err := ReadTOMLWithComments(path, &c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app # not a comment" || c.Server.Port != 8080 {
+		t.Errorf("got %+v", c)
+	}
+
+	want := map[string]string{
+		"name":        "The name of the application.\nShown in logs.",
+		"server":      "The server settings.",
+		"server.host": "The host to bind.",
+	}
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("got comments %q, want %q", comments, want)
+	}
+}
+
+func TestReadTOMLWithCommentsGzip(t *testing.T) {
+	type config struct {
+		Port int `toml:"port"`
+	}
+
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("# The port to listen on.\nport = 8080\n"))
+	zw.Close()
+
+	path := filepath.Join(t.TempDir(), "c.toml.gz")
+
+	err := os.WriteFile(path, buf.Bytes(), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c config
+
+	comments, err := ReadTOMLWithComments(path, &c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Port != 8080 {
+		t.Errorf("got port %d, want 8080", c.Port)
+	}
+
+	if got := comments["port"]; got != "The port to listen on." {
+		t.Errorf("got comment %q, want %q", got, "The port to listen on.")
+	}
+}
+
+func TestReadTOMLWithCommentsEncrypted(t *testing.T) {
+	type config struct {
+		Port int `toml:"port"`
+	}
+
+	detect := func(content []byte) bool { return bytes.HasPrefix(content, xorMagic) }
+
+	path := writeFile(t, t.TempDir(), "c.toml", string(xorEncrypt([]byte("# The port to listen on.\nport = 8080\n"))))
+
+	var c config
+
+	comments, err := ReadTOMLWithComments(path, &c, nil, WithDecrypt(xorDecrypt, detect))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Port != 8080 {
+		t.Errorf("got port %d, want 8080", c.Port)
+	}
+
+	if got := comments["port"]; got != "The port to listen on." {
+		t.Errorf("got comment %q, want the comment of the decrypted content", got)
+	}
+}
+
+func TestReadConfigAutoTOML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.toml", "Name = \"toml\"\n")
+
+	var c testConfig
+
+	_, err := ReadConfigAuto(path, nil, &c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "toml" {
+		t.Errorf("got name %q, want toml", c.Name)
+	}
+}
+
+func TestRoundTripTOML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.toml", "Name = \"app\"\nPort = 80\n")
+
+	testRoundTrip(t, path, TOML[testConfig](), MarshalTOML[testConfig](), func(c *testConfig) {
+		c.Port = 8080
+	})
+}
+
+func TestFormatTOML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.toml", `tags = [ "a",'b' ]
+name='app'
+[server]
+port=8080
+host   = "localhost"
+`)
+
+	got := testFormat(t, path, TOML[tomlConfig](), MarshalTOML[tomlConfig]())
+
+	if !strings.Contains(string(got), `name = "app"`) {
+		t.Errorf("got %q, want canonical quoting and spacing", got)
+	}
+}
+
+func TestTOMLPosition(t *testing.T) {
+	var c testConfig
+
+	err := ReadConfigBytes([]byte("Name = \"app\"\nPort = = 1\n"), &c, TOML[testConfig](), nil)
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Fatalf("got error %v, want an *UnmarshalError", err)
+	}
+
+	if unmarshalErr.Line != 2 {
+		t.Errorf("got line %d, want 2", unmarshalErr.Line)
+	}
+}
+
+func TestTOMLStrict(t *testing.T) {
+	var c testConfig
+
+	err := TOMLStrict[testConfig]()([]byte("Name = \"app\"\ndatabse_url = \"x\"\nBogus = 1\n"), &c)
+	if err == nil || !strings.Contains(err.Error(), `"databse_url"`) || !strings.Contains(err.Error(), `"Bogus"`) {
+		t.Errorf("got error %v, want one naming both unknown keys", err)
+	}
+
+	err = TOMLStrict[testConfig]()([]byte("Name = \"app\"\n"), &c)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerateTemplateTOML(t *testing.T) {
+	type config struct {
+		Name   string `toml:"name" default:"app" comment:"Name of the application."`
+		Server struct {
+			Port int `toml:"port" default:"8080" comment:"Port to listen on."`
+		} `toml:"server" comment:"HTTP server settings."`
+	}
+
+	var c config
+
+	content, err := GenerateTemplate(&c, MarshalTOML[config]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`name = "app" # Name of the application.`,
+		"[server] # HTTP server settings.",
+		"port = 8080 # Port to listen on.",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("template does not contain %q:\n%s", want, content)
+		}
+	}
+
+	var got config
+
+	err = ReadConfigBytes(content, &got, TOML[config](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != c {
+		t.Errorf("got %+v, want %+v", got, c)
+	}
+}
+
+func TestAllowEmptyTOML(t *testing.T) {
+	testAllowEmpty(t, map[string]UnmarshalFunc[*defaultsConfig]{
+		"TOML":       TOML[defaultsConfig](),
+		"TOMLStrict": TOMLStrict[defaultsConfig](),
+	})
+}
@@ -0,0 +1,144 @@
+package gonfig
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is a time.Duration that is unmarshaled from and marshaled to a
+// human-readable string as understood by time.ParseDuration, e.g. "30s" or
+// "1h30m", in any format supporting encoding.TextUnmarshaler, including JSON,
+// TOML, and YAML.
+type Duration time.Duration
+
+// UnmarshalText parses text using time.ParseDuration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// MarshalText formats d using time.Duration.String.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// String formats d using time.Duration.String.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// ByteSize is a number of bytes that is unmarshaled from and marshaled to a
+// human-readable string, e.g. "10MB" or "512KiB", in any format supporting
+// encoding.TextUnmarshaler, including JSON, TOML, and YAML.
+//
+// The decimal units kB, MB, GB, and TB denote powers of 1000, while the binary
+// units KiB, MiB, GiB, and TiB denote powers of 1024. Units are matched
+// case-insensitively and may be separated from the number by white space. A
+// number without a unit or with the unit B denotes bytes.
+type ByteSize int64
+
+// Multiples of ByteSize.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+
+	KiB ByteSize = 1024 * Byte
+	MiB ByteSize = 1024 * KiB
+	GiB ByteSize = 1024 * MiB
+	TiB ByteSize = 1024 * GiB
+)
+
+// byteUnits lists the units of ByteSize, ordered from the largest to the
+// smallest, with binary units preferred when formatting.
+var byteUnits = []struct {
+	name string
+	size ByteSize
+}{
+	{"TiB", TiB}, {"TB", TB},
+	{"GiB", GiB}, {"GB", GB},
+	{"MiB", MiB}, {"MB", MB},
+	{"KiB", KiB}, {"kB", KB},
+	{"B", Byte},
+}
+
+// UnmarshalText parses text as a number of bytes with an optional unit.
+// Fractional numbers are allowed as long as the result is a whole number of
+// bytes, e.g. "1.5KiB". Negative sizes are rejected.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.TrimSpace(s[i:])
+
+	size := Byte
+	if unit != "" {
+		found := false
+
+		for _, u := range byteUnits {
+			if strings.EqualFold(unit, u.name) {
+				size, found = u.size, true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+		}
+	}
+
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return fmt.Errorf("invalid byte size %q", s)
+	}
+
+	v *= float64(size)
+
+	switch {
+	case v < 0:
+		return fmt.Errorf("invalid byte size %q: must not be negative", s)
+	case v >= math.MaxInt64:
+		return fmt.Errorf("invalid byte size %q: too large", s)
+	case v != math.Trunc(v):
+		return fmt.Errorf("invalid byte size %q: not a whole number of bytes", s)
+	}
+
+	*b = ByteSize(v)
+
+	return nil
+}
+
+// MarshalText formats b using the largest unit that represents it exactly,
+// e.g. "10MiB" or "1500B".
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// String formats b using the largest unit that represents it exactly.
+func (b ByteSize) String() string {
+	for _, u := range byteUnits {
+		if b != 0 && b%u.size == 0 {
+			return strconv.FormatInt(int64(b/u.size), 10) + u.name
+		}
+	}
+
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
@@ -0,0 +1,119 @@
+package gonfig
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type unitsConfig struct {
+	Timeout Duration `json:"timeout"`
+	MaxSize ByteSize `json:"max_size"`
+}
+
+func TestUnits(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"timeout": "30s", "max_size": "10MB"}`)
+
+	var c unitsConfig
+
+	_, err := ReadConfig(path, nil, &c, JSON[unitsConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (unitsConfig{Timeout: Duration(30 * time.Second), MaxSize: 10 * MB}); c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+
+	content, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"timeout":"30s","max_size":"10MB"}`; string(content) != want {
+		t.Errorf("got %s, want %s", content, want)
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    ByteSize
+		wantErr bool
+	}{
+		{"512", 512, false},
+		{"0", 0, false},
+		{"1B", Byte, false},
+		{"10MB", 10 * MB, false},
+		{"10 mb", 10 * MB, false},
+		{"512KiB", 512 * KiB, false},
+		{"1.5KiB", 1536, false},
+		{"2GiB", 2 * GiB, false},
+		{"10XB", 0, true},
+		{"MB", 0, true},
+		{"1.5B", 0, true},
+		{"-1MB", 0, true},
+		{"10000000TiB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var b ByteSize
+
+			err := b.UnmarshalText([]byte(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+
+			if b != tt.want {
+				t.Errorf("got %d, want %d", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	for b, want := range map[ByteSize]string{0: "0B", 1500: "1500B", 10 * MB: "10MB", 3 * MiB: "3MiB", 1500 * KB: "1500kB"} {
+		if got := b.String(); got != want {
+			t.Errorf("got %q for %d, want %q", got, b, want)
+		}
+	}
+}
+
+func TestDurationInvalid(t *testing.T) {
+	for _, text := range []string{"30", "30 parsecs", ""} {
+		var d Duration
+
+		err := d.UnmarshalText([]byte(text))
+		if err == nil {
+			t.Errorf("got no error for %q", text)
+		}
+	}
+}
+
+func TestPositiveValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       unitsConfig
+		wantErr bool
+	}{
+		{"positive", unitsConfig{Timeout: Duration(time.Second), MaxSize: KiB}, false},
+		{"zero duration", unitsConfig{MaxSize: KiB}, true},
+		{"negative duration", unitsConfig{Timeout: Duration(-time.Second), MaxSize: KiB}, true},
+		{"zero size", unitsConfig{Timeout: Duration(time.Second)}, true},
+	}
+
+	validate := CombineValidators(
+		Field("timeout", func(c *unitsConfig) Duration { return c.Timeout }, PositiveValue(func(d Duration) Duration { return d })),
+		Field("max_size", func(c *unitsConfig) ByteSize { return c.MaxSize }, PositiveValue(func(b ByteSize) ByteSize { return b })),
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
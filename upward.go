@@ -0,0 +1,51 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FindConfigUpward searches for the configuration file fileName in startDir
+// and its parent directories, up to the root of the file system, similar to
+// how git locates the .git directory. This lets tools find a project-level
+// configuration file from any subdirectory of the project. An empty startDir
+// starts at the current working directory.
+//
+// Returns the path of the file in the nearest directory, or an error
+// satisfying ErrConfigNotFound listing the searched paths if there is none.
+func FindConfigUpward(fileName string, startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve directory %s: %w", startDir, err)
+	}
+
+	var tried []string
+
+	for {
+		path := filepath.Join(dir, fileName)
+		tried = append(tried, path)
+
+		info, err := os.Stat(path)
+		if err == nil {
+			err = checkRegular(path, info)
+			if err != nil {
+				return "", err
+			}
+
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("could not stat configuration file %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", &NotFoundError{Paths: tried}
+		}
+
+		dir = parent
+	}
+}
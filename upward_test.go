@@ -0,0 +1,65 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindConfigUpward(t *testing.T) {
+	root := t.TempDir()
+	want := writeFile(t, root, "project.json", `{}`)
+
+	start := filepath.Join(root, "a", "b", "c")
+
+	err := os.MkdirAll(start, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A nearer file shadows the one further up.
+	nearer := writeFile(t, filepath.Join(root, "a"), "project.json", `{}`)
+
+	got, err := FindConfigUpward("project.json", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != nearer {
+		t.Errorf("got %q, want %q", got, nearer)
+	}
+
+	err = os.Remove(nearer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = FindConfigUpward("project.json", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFindConfigUpwardAbsent(t *testing.T) {
+	start := filepath.Join(t.TempDir(), "a", "b")
+
+	err := os.MkdirAll(start, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FindConfigUpward("gonfig-test-absent.json", start)
+	if !errors.Is(err, ErrConfigNotFound) {
+		t.Fatalf("got error %v, want it to match ErrConfigNotFound", err)
+	}
+
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || notFound.Paths[0] != filepath.Join(start, "gonfig-test-absent.json") {
+		t.Errorf("got error %v, want the searched paths to start at %s", err, start)
+	}
+}
@@ -0,0 +1,53 @@
+package gonfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+)
+
+// ReadConfigURL reads a configuration from the given URL, unmarshals its
+// content into the given configuration object, and validates it.
+//
+// file:// URLs are read from the file system, while http:// and https:// URLs
+// are fetched with a GET request bound to ctx using the client set by
+// WithHTTPClient, defaulting to http.DefaultClient. Responses with a status
+// code other than 2xx are reported as errors.
+func ReadConfigURL[T any](ctx context.Context, rawurl string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], opts ...Option) error {
+	o := newOptions(opts)
+
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return fmt.Errorf("invalid configuration URL %s: %w", rawurl, err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		if u.Host != "" && u.Host != "localhost" {
+			return fmt.Errorf("unsupported host %s in configuration URL %s", u.Host, rawurl)
+		}
+
+		return readFoundConfig(filepath.FromSlash(u.Path), c, unmarshal, finalize, o)
+	case "http", "https":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawurl, nil)
+		if err != nil {
+			return fmt.Errorf("invalid configuration URL %s: %w", rawurl, err)
+		}
+
+		resp, err := o.httpClient().Do(req)
+		if err != nil {
+			return fmt.Errorf("unable to fetch configuration %s: %w", rawurl, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unable to fetch configuration %s: unexpected status %s", rawurl, resp.Status)
+		}
+
+		return readConfig(rawurl, resp.Body, c, unmarshal, finalize, o)
+	default:
+		return fmt.Errorf("unsupported scheme %q in configuration URL %s", u.Scheme, rawurl)
+	}
+}
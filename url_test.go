@@ -0,0 +1,83 @@
+package gonfig
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigURLHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(`{"Name": "remote"}`))
+	}))
+	defer srv.Close()
+
+	var c testConfig
+
+	err := ReadConfigURL(context.Background(), srv.URL+"/config.json", &c, JSON[testConfig](), nil, WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "remote" {
+		t.Errorf("got name %q, want remote", c.Name)
+	}
+
+	err = ReadConfigURL(context.Background(), srv.URL+"/missing.json", &c, JSON[testConfig](), nil, WithHTTPClient(srv.Client()))
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("got error %v, want one including the status code", err)
+	}
+}
+
+func TestReadConfigURLContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var c testConfig
+
+	err := ReadConfigURL(ctx, srv.URL, &c, JSON[testConfig](), nil)
+	if err == nil {
+		t.Error("got no error for a cancelled context")
+	}
+}
+
+func TestReadConfigURLFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file URLs of Windows paths are not tested")
+	}
+
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "local"}`)
+
+	var c testConfig
+
+	err := ReadConfigURL(context.Background(), "file://"+path, &c, JSON[testConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "local" {
+		t.Errorf("got name %q, want local", c.Name)
+	}
+}
+
+func TestReadConfigURLUnsupportedScheme(t *testing.T) {
+	var c testConfig
+
+	err := ReadConfigURL(context.Background(), "ftp://example.com/config.json", &c, JSON[testConfig](), nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported scheme") {
+		t.Errorf("got error %v, want an unsupported scheme error", err)
+	}
+}
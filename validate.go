@@ -0,0 +1,829 @@
+package gonfig
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CombineValidators returns a FinalizeFunc that runs all given validators and
+// joins their errors using errors.Join, so that every problem is reported at
+// once. The underlying errors remain accessible using errors.Is and errors.As.
+func CombineValidators[T any](validators ...FinalizeFunc[T]) FinalizeFunc[T] {
+	return func(c T) error {
+		var errs []error
+
+		for _, validate := range validators {
+			err := finalizeConfig(c, validate)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// CombineValidatorsFailFast is like CombineValidators, but stops at the first
+// validator that fails and returns its error, skipping the remaining ones.
+// This suits long validator chains where later validators are expensive, e.g.
+// because they perform I/O, or depend on earlier ones passing.
+func CombineValidatorsFailFast[T any](validators ...FinalizeFunc[T]) FinalizeFunc[T] {
+	return func(c T) error {
+		for _, validate := range validators {
+			err := finalizeConfig(c, validate)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// When returns a FinalizeFunc that only runs then if cond reports true for
+// the configuration object. This expresses constraints spanning multiple
+// fields declaratively, e.g. requiring a certificate only if TLS is enabled:
+//
+//	gonfig.When(func(c *Config) bool { return c.TLS }, func(c *Config) error {
+//		if c.CertFile == "" {
+//			return errors.New("cert file is required when TLS is enabled")
+//		}
+//		return nil
+//	})
+//
+// Any other cross-field constraint can be written as a plain FinalizeFunc and
+// composed with CombineValidators.
+func When[T any](cond func(T) bool, then FinalizeFunc[T]) FinalizeFunc[T] {
+	return func(c T) error {
+		if !cond(c) {
+			return nil
+		}
+
+		return finalizeConfig(c, then)
+	}
+}
+
+// Unless is the inverse of When: it returns a FinalizeFunc that runs validate
+// only if skip reports false for the configuration object, e.g. to skip
+// checking upstream addresses while the application runs in offline mode.
+func Unless[T any](skip func(T) bool, validate FinalizeFunc[T]) FinalizeFunc[T] {
+	return func(c T) error {
+		if skip(c) {
+			return nil
+		}
+
+		return finalizeConfig(c, validate)
+	}
+}
+
+// Field returns a FinalizeFunc that runs validate on the field of the
+// configuration object returned by get and prefixes its error with name, so
+// that errors of validators shared by multiple fields identify the offending
+// one, e.g. "database.port: out of range". The underlying error remains
+// accessible using errors.Is and errors.As.
+func Field[T, F any](name string, get func(T) F, validate func(F) error) FinalizeFunc[T] {
+	return func(c T) error {
+		err := validate(get(c))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		return nil
+	}
+}
+
+// OneOf returns a FinalizeFunc that reports an error unless exactly one of the
+// given predicates reports true for the configuration object, e.g. to require
+// exactly one authentication method. Each predicate reports whether a member
+// of the group is set. The error message names the group using name.
+func OneOf[T any](name string, set ...func(T) bool) FinalizeFunc[T] {
+	return func(c T) error {
+		switch countSet(c, set) {
+		case 0:
+			return fmt.Errorf("exactly one of %s must be set, but none is", name)
+		case 1:
+			return nil
+		default:
+			return fmt.Errorf("exactly one of %s must be set, but multiple are", name)
+		}
+	}
+}
+
+// AtMostOne is like OneOf, but also accepts none of the predicates reporting
+// true.
+func AtMostOne[T any](name string, set ...func(T) bool) FinalizeFunc[T] {
+	return func(c T) error {
+		if countSet(c, set) > 1 {
+			return fmt.Errorf("at most one of %s may be set, but multiple are", name)
+		}
+
+		return nil
+	}
+}
+
+// AtLeastOne is like OneOf, but also accepts multiple predicates reporting
+// true.
+func AtLeastOne[T any](name string, set ...func(T) bool) FinalizeFunc[T] {
+	return func(c T) error {
+		if countSet(c, set) == 0 {
+			return fmt.Errorf("at least one of %s must be set, but none is", name)
+		}
+
+		return nil
+	}
+}
+
+// countSet returns the number of predicates reporting true for c.
+func countSet[T any](c T, set []func(T) bool) int {
+	n := 0
+
+	for _, isSet := range set {
+		if isSet(c) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Enum returns a FinalizeFunc that reports an error listing the allowed
+// values if the value returned by get is not one of them, e.g. to restrict a
+// log level to debug, info, warn, or error. Values are compared
+// case-sensitively. Combine it with Field to name the offending field.
+func Enum[T any, S ~string](get func(T) S, allowed ...S) FinalizeFunc[T] {
+	return enum(get, allowed, func(a, b string) bool { return a == b })
+}
+
+// EnumFold is like Enum, but compares values case-insensitively.
+func EnumFold[T any, S ~string](get func(T) S, allowed ...S) FinalizeFunc[T] {
+	return enum(get, allowed, strings.EqualFold)
+}
+
+func enum[T any, S ~string](get func(T) S, allowed []S, equal func(a, b string) bool) FinalizeFunc[T] {
+	return func(c T) error {
+		value := get(c)
+
+		names := make([]string, len(allowed))
+		for i, a := range allowed {
+			if equal(string(value), string(a)) {
+				return nil
+			}
+
+			names[i] = string(a)
+		}
+
+		return fmt.Errorf("value %q is not one of %s", value, strings.Join(names, ", "))
+	}
+}
+
+// IntEnum is like Enum, but for integer codes that map to Go constants,
+// e.g. a compression level. The error lists the allowed codes as integers,
+// since those are what appears in the configuration file.
+func IntEnum[T any, E ~int](get func(T) E, allowed ...E) FinalizeFunc[T] {
+	return func(c T) error {
+		value := get(c)
+
+		if slices.Contains(allowed, value) {
+			return nil
+		}
+
+		codes := make([]string, len(allowed))
+		for i, a := range allowed {
+			codes[i] = strconv.Itoa(int(a))
+		}
+
+		return fmt.Errorf("value %d is not one of %s", int(value), strings.Join(codes, ", "))
+	}
+}
+
+// VersionedValidator returns a FinalizeFunc that runs the validator of
+// byVersion matching the version of the configuration object returned by
+// get, so that the validation can evolve along with the schema of the
+// configuration file. Returns an error listing the supported versions for
+// versions without a validator. A version of 0, which is what an omitted
+// version field unmarshals to, is only accepted if byVersion has an entry for
+// it, e.g. as an alias of the oldest version.
+func VersionedValidator[T any](get func(T) int, byVersion map[int]FinalizeFunc[T]) FinalizeFunc[T] {
+	return func(c T) error {
+		version := get(c)
+
+		validate, ok := byVersion[version]
+		if !ok {
+			versions := make([]string, 0, len(byVersion))
+			for _, v := range slices.Sorted(maps.Keys(byVersion)) {
+				versions = append(versions, strconv.Itoa(v))
+			}
+
+			return fmt.Errorf("unsupported configuration version %d, supported are %s", version, strings.Join(versions, ", "))
+		}
+
+		return finalizeConfig(c, validate)
+	}
+}
+
+// number is the constraint of numeric types.
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// PositiveValue returns a FinalizeFunc that reports an error if the number
+// returned by get is not greater than zero, e.g. for a timeout of type
+// Duration or a limit of type ByteSize. Combine it with Field to name the
+// offending field.
+func PositiveValue[T any, N number](get func(T) N) FinalizeFunc[T] {
+	return func(c T) error {
+		value := get(c)
+		if value <= 0 {
+			return fmt.Errorf("value %v must be positive", value)
+		}
+
+		return nil
+	}
+}
+
+// UniqueBy returns a FinalizeFunc that reports an error for every key that
+// occurs more than once among the elements of the slice returned by get, as
+// returned by key, e.g. to catch servers that share a name because of a
+// copy-paste mistake. The error names the duplicate key and the indices of
+// the offending elements. The errors of all duplicate keys are joined.
+func UniqueBy[T, E any, K comparable](get func(T) []E, key func(E) K) FinalizeFunc[T] {
+	return func(c T) error {
+		var (
+			errs    []error
+			keys    []K
+			indices = map[K][]int{}
+		)
+
+		for i, e := range get(c) {
+			k := key(e)
+			if _, ok := indices[k]; !ok {
+				keys = append(keys, k)
+			}
+
+			indices[k] = append(indices[k], i)
+		}
+
+		for _, k := range keys {
+			if len(indices[k]) > 1 {
+				errs = append(errs, fmt.Errorf("duplicate key %v at indices %v", k, indices[k]))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// EachValue returns a FinalizeFunc that runs validate on every value of the
+// map returned by get, e.g. on each entry of a map[string]ServerConfig, and
+// prefixes each error with the key of the offending entry, e.g.
+// "[primary]: missing address". The entries are validated in the order of
+// their formatted keys, so that the errors are reported deterministically.
+// The errors of all invalid entries are joined.
+func EachValue[T any, K comparable, V any](get func(T) map[K]V, validate FinalizeFunc[V]) FinalizeFunc[T] {
+	return func(c T) error {
+		m := get(c)
+
+		keys := slices.SortedFunc(maps.Keys(m), func(a, b K) int {
+			return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+
+		var errs []error
+
+		for _, k := range keys {
+			err := validate(m[k])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("[%v]: %w", k, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// RequiredFields returns a FinalizeFunc that reports an error for every field
+// tagged with `gonfig:"required"` that holds its zero value. Nested structs
+// and non-nil pointers to structs are checked as well. The errors of all
+// missing fields are joined.
+//
+// Pointer and interface fields, e.g. optional sub-configurations, are missing
+// if they are nil, which is reported as such, while a non-nil pointer to a
+// zero value counts as set.
+func RequiredFields[T any]() FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			if !parseTag(path[len(path)-1]).has("required") || !v.IsZero() {
+				return nil
+			}
+
+			switch v.Kind() {
+			case reflect.Pointer, reflect.Interface:
+				errs = append(errs, fmt.Errorf("required field %s is nil", fieldPath(path)))
+			default:
+				errs = append(errs, fmt.Errorf("required field %s is not set", fieldPath(path)))
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// PathsExist returns a FinalizeFunc that reports an error for every field
+// tagged with `gonfig:"path"` that does not name an existing and accessible
+// file or directory. The tag applies to string fields and slices of strings.
+//
+// Empty paths are reported as missing, unless the field is tagged with
+// `gonfig:"path,optional"`. The errors of all missing paths are joined.
+func PathsExist[T any]() FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			opts := parseTag(path[len(path)-1])
+			if !opts.has("path") {
+				return nil
+			}
+
+			values := stringValues(v)
+			if len(values) == 0 && !opts.has("optional") {
+				errs = append(errs, fmt.Errorf("path field %s is not set", fieldPath(path)))
+			}
+
+			for _, p := range values {
+				if p == "" {
+					if !opts.has("optional") {
+						errs = append(errs, fmt.Errorf("path field %s is not set", fieldPath(path)))
+					}
+
+					continue
+				}
+
+				_, err := os.Stat(p)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("path field %s: %w", fieldPath(path), err))
+				}
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// IPAddr returns a FinalizeFunc that reports an error for every value of a
+// field tagged with `gonfig:"ip"` that is not an IP address as parsed by
+// net.ParseIP, e.g. 192.0.2.1 or 2001:db8::1. The tag applies to string
+// fields and slices of strings, whose elements are checked individually.
+// Empty values are skipped. The errors of all invalid values are joined.
+func IPAddr[T any]() FinalizeFunc[*T] {
+	return stringValidator[T]("ip", func(s string) error {
+		if net.ParseIP(s) == nil {
+			return fmt.Errorf("invalid IP address %q", s)
+		}
+
+		return nil
+	})
+}
+
+// CIDR is like IPAddr, but checks fields tagged with `gonfig:"cidr"` for
+// networks in CIDR notation as parsed by net.ParseCIDR, e.g. 192.0.2.0/24.
+func CIDR[T any]() FinalizeFunc[*T] {
+	return stringValidator[T]("cidr", func(s string) error {
+		_, _, err := net.ParseCIDR(s)
+		return err
+	})
+}
+
+// stringValidator returns a FinalizeFunc that reports an error for every
+// non-empty value of a field tagged with the option opt that is rejected by
+// check.
+func stringValidator[T any](opt string, check func(string) error) FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			if !parseTag(path[len(path)-1]).has(opt) {
+				return nil
+			}
+
+			for _, s := range stringValues(v) {
+				if s == "" {
+					continue
+				}
+
+				err := check(s)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("field %s: %w", fieldPath(path), err))
+				}
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// Bounds returns a FinalizeFunc that reports an error for every integer,
+// floating-point, or duration field whose value lies outside the bounds given
+// by its struct tag, e.g. `gonfig:"min=1,max=65535"`. Either bound may be
+// omitted. For time.Duration and Duration fields, the bounds are durations as
+// parsed by time.ParseDuration, e.g. `gonfig:"min=1s,max=5m"`. The errors of
+// all out-of-range fields and malformed bounds are joined.
+func Bounds[T any]() FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			opts := parseTag(path[len(path)-1])
+
+			for _, bound := range []string{"min", "max"} {
+				limit, ok := opts[bound]
+				if !ok {
+					continue
+				}
+
+				err := checkBound(v, bound, limit)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("field %s: %w", fieldPath(path), err))
+				}
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// LenBounds returns a FinalizeFunc that reports an error for every slice,
+// array, map, or string field whose length lies outside the bounds given by
+// its struct tag, e.g. `gonfig:"minlen=1,maxlen=10"` to require at least one
+// and at most ten upstreams. Either bound may be omitted. The errors of all
+// offending fields and malformed bounds are joined.
+func LenBounds[T any]() FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			opts := parseTag(path[len(path)-1])
+
+			for _, bound := range []string{"minlen", "maxlen"} {
+				limit, ok := opts[bound]
+				if !ok {
+					continue
+				}
+
+				err := checkLenBound(v, bound, limit)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("field %s: %w", fieldPath(path), err))
+				}
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// checkLenBound checks the length of v against the bound of the given kind,
+// minlen or maxlen.
+func checkLenBound(v reflect.Value, bound, limit string) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+	default:
+		return fmt.Errorf("%s bound is not supported for type %s", bound, v.Type())
+	}
+
+	l, err := strconv.Atoi(limit)
+	if err != nil {
+		return fmt.Errorf("invalid %s bound %q: %w", bound, limit, err)
+	}
+
+	if bound == "minlen" && v.Len() < l {
+		return fmt.Errorf("length %d is less than the minimum of %d", v.Len(), l)
+	}
+
+	if bound == "maxlen" && v.Len() > l {
+		return fmt.Errorf("length %d is greater than the maximum of %d", v.Len(), l)
+	}
+
+	return nil
+}
+
+// Positive returns a FinalizeFunc that reports an error for every integer,
+// floating-point, or time.Duration field tagged with `gonfig:"positive"`
+// whose value is not greater than zero. The errors of all offending fields
+// are joined.
+func Positive[T any]() FinalizeFunc[*T] {
+	return signValidator[T]("positive", "must be positive", func(sign int) bool { return sign > 0 })
+}
+
+// NonNegative is like Positive, but only reports fields tagged with
+// `gonfig:"nonneg"` whose value is less than zero, so that zero passes.
+func NonNegative[T any]() FinalizeFunc[*T] {
+	return signValidator[T]("nonneg", "must not be negative", func(sign int) bool { return sign >= 0 })
+}
+
+// signValidator returns a FinalizeFunc that reports an error for every
+// numeric field tagged with the option opt whose sign is not accepted by ok.
+func signValidator[T any](opt, msg string, ok func(sign int) bool) FinalizeFunc[*T] {
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			if !parseTag(path[len(path)-1]).has(opt) {
+				return nil
+			}
+
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return nil
+				}
+
+				v = v.Elem()
+			}
+
+			var sign int
+
+			switch v.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				sign = cmp.Compare(v.Int(), 0)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				sign = cmp.Compare(v.Uint(), 0)
+			case reflect.Float32, reflect.Float64:
+				sign = cmp.Compare(v.Float(), 0)
+			default:
+				errs = append(errs, fmt.Errorf("field %s: %s is not supported for %s", fieldPath(path), opt, v.Type()))
+				return nil
+			}
+
+			if !ok(sign) {
+				errs = append(errs, fmt.Errorf("field %s: value %v %s", fieldPath(path), v.Interface(), msg))
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// Pattern returns a FinalizeFunc that reports an error for every string field
+// tagged with a regular expression, e.g. `gonfig:"pattern=^[a-z0-9-]+$"`,
+// whose value does not match it. Each error names the field and the pattern.
+// Since the pattern may contain commas, it must be the last option of the
+// tag. The errors of all offending fields are joined.
+//
+// The patterns are compiled once when Pattern is called. It panics if a tag
+// holds an invalid regular expression, which is a programming error much like
+// an invalid argument to regexp.MustCompile.
+func Pattern[T any]() FinalizeFunc[*T] {
+	patterns := map[string]*regexp.Regexp{}
+	compilePatterns(reflect.TypeFor[T](), patterns, map[reflect.Type]bool{})
+
+	return func(c *T) error {
+		var errs []error
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			pattern, ok := parseTag(path[len(path)-1])["pattern"]
+			if !ok {
+				return nil
+			}
+
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return nil
+				}
+
+				v = v.Elem()
+			}
+
+			if v.Kind() != reflect.String {
+				errs = append(errs, fmt.Errorf("field %s: pattern is not supported for %s", fieldPath(path), v.Type()))
+				return nil
+			}
+
+			if !patterns[pattern].MatchString(v.String()) {
+				errs = append(errs, fmt.Errorf("field %s: value %q does not match pattern %s", fieldPath(path), v.String(), pattern))
+			}
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// compilePatterns compiles the patterns of all fields of the struct type t
+// and its nested structs into patterns, skipping types already in seen.
+func compilePatterns(t reflect.Type, patterns map[string]*regexp.Regexp, seen map[reflect.Type]bool) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		pattern, ok := parseTag(sf)["pattern"]
+		if ok && patterns[pattern] == nil {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				panic(fmt.Sprintf("gonfig: invalid pattern for field %s: %v", sf.Name, err))
+			}
+
+			patterns[pattern] = re
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if isStruct(ft) {
+			compilePatterns(ft, patterns, seen)
+		}
+	}
+}
+
+// DistinctListeners returns a FinalizeFunc that reports an error for every
+// pair of string fields tagged with `gonfig:"listen"` whose addresses
+// overlap, e.g. when the HTTP and metrics listeners are both configured to
+// bind :8080. Empty fields are skipped.
+//
+// Addresses are normalized before comparing them: an empty host, 0.0.0.0,
+// and :: all denote every interface, so that :8080 and 0.0.0.0:8080 are
+// reported as duplicates. Since such addresses cannot be bound alongside a
+// specific host on the same port either, they also overlap with any other
+// address with that port. The errors of all overlapping pairs and
+// malformed addresses are joined.
+func DistinctListeners[T any]() FinalizeFunc[*T] {
+	return func(c *T) error {
+		type listener struct {
+			field, addr string
+			host, port  string
+		}
+
+		var (
+			errs      []error
+			listeners []listener
+		)
+
+		walkFields(reflect.ValueOf(c).Elem(), func(path []reflect.StructField, v reflect.Value) error {
+			if !parseTag(path[len(path)-1]).has("listen") {
+				return nil
+			}
+
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return nil
+				}
+
+				v = v.Elem()
+			}
+
+			if v.Kind() != reflect.String {
+				errs = append(errs, fmt.Errorf("field %s: listen is not supported for %s", fieldPath(path), v.Type()))
+				return nil
+			}
+
+			if v.String() == "" {
+				return nil
+			}
+
+			host, port, err := normalizeListenAddr(v.String())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("field %s: %w", fieldPath(path), err))
+				return nil
+			}
+
+			l := listener{fieldPath(path), v.String(), host, port}
+
+			for _, other := range listeners {
+				if other.port == l.port && (other.host == l.host || other.host == "" || l.host == "") {
+					errs = append(errs, fmt.Errorf("fields %s and %s: addresses %s and %s overlap", other.field, l.field, other.addr, l.addr))
+				}
+			}
+
+			listeners = append(listeners, l)
+
+			return nil
+		})
+
+		return errors.Join(errs...)
+	}
+}
+
+// normalizeListenAddr splits the listen address addr into its host and port,
+// mapping the wildcard hosts to the empty host and normalizing IP addresses
+// and numeric ports.
+func normalizeListenAddr(addr string) (host, port string, err error) {
+	host, port, err = net.SplitHostPort(addr)
+	if err != nil {
+		return "", "", err
+	}
+
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err == nil {
+		port = strconv.FormatUint(n, 10)
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err == nil {
+		ip = ip.Unmap()
+		if ip.IsUnspecified() {
+			return "", port, nil
+		}
+
+		return ip.String(), port, nil
+	}
+
+	return strings.ToLower(host), port, nil
+}
+
+// checkBound checks the numeric value v against the given bound, which is
+// either min or max, with the limit given in the struct tag.
+func checkBound(v reflect.Value, bound, limit string) error {
+	var order int
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Type() == durationType || v.Type() == reflect.TypeFor[Duration]():
+		l, err := time.ParseDuration(limit)
+		if err != nil {
+			return fmt.Errorf("invalid %s bound %q: %w", bound, limit, err)
+		}
+
+		order = cmp.Compare(v.Int(), int64(l))
+	case v.CanInt():
+		l, err := strconv.ParseInt(limit, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s bound %q: %w", bound, limit, err)
+		}
+
+		order = cmp.Compare(v.Int(), l)
+	case v.CanUint():
+		l, err := strconv.ParseUint(limit, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s bound %q: %w", bound, limit, err)
+		}
+
+		order = cmp.Compare(v.Uint(), l)
+	case v.CanFloat():
+		l, err := strconv.ParseFloat(limit, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s bound %q: %w", bound, limit, err)
+		}
+
+		order = cmp.Compare(v.Float(), l)
+	default:
+		return fmt.Errorf("%s bound is not supported for type %s", bound, v.Type())
+	}
+
+	if bound == "min" && order < 0 {
+		return fmt.Errorf("value %v is less than the minimum of %s", v.Interface(), limit)
+	}
+
+	if bound == "max" && order > 0 {
+		return fmt.Errorf("value %v is greater than the maximum of %s", v.Interface(), limit)
+	}
+
+	return nil
+}
@@ -0,0 +1,998 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCombineValidators(t *testing.T) {
+	errPort := errors.New("port is required")
+
+	validate := CombineValidators(
+		func(c *testConfig) error { return errors.New("name is required") },
+		func(c *testConfig) error { return nil },
+		func(c *testConfig) error { return errPort },
+		func(c *testConfig) error { return &fs.PathError{Op: "open", Path: "cert.pem", Err: fs.ErrNotExist} },
+	)
+
+	err := validate(&testConfig{})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	for _, msg := range []string{"name is required", "port is required", "cert.pem"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q does not contain %q", err, msg)
+		}
+	}
+
+	if !errors.Is(err, errPort) {
+		t.Errorf("got error %v, want it to match errPort", err)
+	}
+
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) {
+		t.Errorf("got error %v, want it to contain an *fs.PathError", err)
+	}
+}
+
+func TestCombineValidatorsPass(t *testing.T) {
+	validate := CombineValidators(func(*testConfig) error { return nil }, nil)
+
+	err := validate(&testConfig{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCombineValidatorsFailFast(t *testing.T) {
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+
+	tests := []struct {
+		name    string
+		combine func(...FinalizeFunc[*testConfig]) FinalizeFunc[*testConfig]
+		want    []string
+	}{
+		{"fail fast", CombineValidatorsFailFast[*testConfig], []string{"pass", "first"}},
+		{"collect all", CombineValidators[*testConfig], []string{"pass", "first", "second", "last"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+
+			validator := func(name string, err error) FinalizeFunc[*testConfig] {
+				return func(*testConfig) error {
+					ran = append(ran, name)
+					return err
+				}
+			}
+
+			err := tt.combine(
+				validator("pass", nil),
+				validator("first", errFirst),
+				validator("second", errSecond),
+				validator("last", nil),
+			)(&testConfig{})
+
+			if !slices.Equal(ran, tt.want) {
+				t.Errorf("got validators %q run, want %q", ran, tt.want)
+			}
+
+			if !errors.Is(err, errFirst) || errors.Is(err, errSecond) != (len(tt.want) > 2) {
+				t.Errorf("got error %v", err)
+			}
+		})
+	}
+
+	err := CombineValidatorsFailFast(func(*testConfig) error { return nil }, nil)(&testConfig{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	type database struct {
+		URL  string `gonfig:"required"`
+		Port int
+	}
+
+	type config struct {
+		Name     string `gonfig:"required"`
+		Port     int    `gonfig:"required"`
+		Database database
+		Cache    *database
+		TLS      *struct{} `gonfig:"required"`
+	}
+
+	c := config{Port: 8080, Cache: &database{URL: "redis://cache"}}
+
+	err := RequiredFields[config]()(&c)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	for _, msg := range []string{"Name is not set", "Database.URL is not set", "TLS is nil"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q does not contain %q", err, msg)
+		}
+	}
+
+	for _, msg := range []string{"Port", "Cache"} {
+		if strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q mentions the set field %s", err, msg)
+		}
+	}
+
+	c = config{Name: "app", Port: 8080, Database: database{URL: "postgres://db"}, TLS: &struct{}{}}
+
+	err = RequiredFields[config]()(&c)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRequiredFieldsNil(t *testing.T) {
+	type tls struct {
+		CertFile string
+	}
+
+	type nested struct {
+		Cache *tls `gonfig:"required"`
+	}
+
+	type config struct {
+		TLS    *tls         `gonfig:"required"`
+		Store  fmt.Stringer `gonfig:"required"`
+		Nested nested
+	}
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr []string
+	}{
+		{"nil", config{}, []string{"TLS is nil", "Store is nil", "Nested.Cache is nil"}},
+		{"non-nil zero values", config{TLS: &tls{}, Store: time.Duration(0), Nested: nested{Cache: &tls{}}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RequiredFields[config]()(&tt.c)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			for _, msg := range tt.wantErr {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("error %q does not contain %q", err, msg)
+				}
+			}
+
+			if strings.Contains(err.Error(), "not set") {
+				t.Errorf("error %q reports a nil field as not set", err)
+			}
+		})
+	}
+}
+
+func TestPathsExist(t *testing.T) {
+	dir := t.TempDir()
+	cert := writeFile(t, dir, "cert.pem", "")
+	missing := filepath.Join(dir, "missing.pem")
+
+	type config struct {
+		Cert     string   `gonfig:"path"`
+		Key      string   `gonfig:"path"`
+		DataDir  string   `gonfig:"path"`
+		Plugins  []string `gonfig:"path"`
+		CA       string   `gonfig:"path,optional"`
+		Socket   string   `gonfig:"path"`
+		Untagged string
+	}
+
+	c := config{
+		Cert:     cert,
+		Key:      missing,
+		DataDir:  dir,
+		Plugins:  []string{cert, filepath.Join(dir, "plugin.so")},
+		Untagged: missing,
+	}
+
+	err := PathsExist[config]()(&c)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	for _, msg := range []string{"Key", missing, "Plugins", "plugin.so", "Socket is not set"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q does not contain %q", err, msg)
+		}
+	}
+
+	for _, msg := range []string{"Cert", "DataDir", "CA", "Untagged"} {
+		if strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q mentions the valid field %s", err, msg)
+		}
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got error %v, want it to match fs.ErrNotExist", err)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	type config struct {
+		Port    int     `gonfig:"min=1,max=65535"`
+		Workers uint    `gonfig:"min=1"`
+		Ratio   float64 `gonfig:"min=0,max=1"`
+		Retries int8    `gonfig:"max=5"`
+	}
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr []string
+	}{
+		{"in range", config{Port: 8080, Workers: 4, Ratio: 0.5, Retries: -3}, nil},
+		{"at the bounds", config{Port: 65535, Workers: 1, Ratio: 1, Retries: 5}, nil},
+		{"below min", config{Port: 0, Workers: 0, Ratio: -0.1}, []string{"Port", "Workers", "Ratio"}},
+		{"above max", config{Port: 70000, Workers: 1, Ratio: 1.5, Retries: 6}, []string{"Port", "Ratio", "Retries"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bounds[config]()(&tt.c)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			for _, field := range tt.wantErr {
+				if !strings.Contains(err.Error(), "field "+field+":") {
+					t.Errorf("error %q does not name %s", err, field)
+				}
+			}
+		})
+	}
+}
+
+func TestBoundsMalformed(t *testing.T) {
+	type config struct {
+		Port int `gonfig:"min=one"`
+	}
+
+	err := Bounds[config]()(&config{})
+	if err == nil {
+		t.Error("got no error for a malformed bound")
+	}
+}
+
+func TestBoundsDuration(t *testing.T) {
+	type config struct {
+		Timeout time.Duration `gonfig:"min=1s,max=5m"`
+		Retry   Duration      `gonfig:"min=100ms"`
+	}
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr []string
+	}{
+		{"in range", config{Timeout: 30 * time.Second, Retry: Duration(time.Second)}, nil},
+		{"at the bounds", config{Timeout: 5 * time.Minute, Retry: Duration(100 * time.Millisecond)}, nil},
+		{"below min", config{Timeout: 500 * time.Millisecond, Retry: Duration(time.Millisecond)}, []string{"Timeout", "Retry"}},
+		{"above max", config{Timeout: 10 * time.Minute, Retry: Duration(time.Hour)}, []string{"Timeout"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bounds[config]()(&tt.c)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			for _, field := range tt.wantErr {
+				if !strings.Contains(err.Error(), "field "+field+":") {
+					t.Errorf("error %q does not name %s", err, field)
+				}
+			}
+
+			if len(tt.wantErr) == 1 && strings.Contains(err.Error(), "Retry") {
+				t.Errorf("error %q names Retry, which is in range", err)
+			}
+		})
+	}
+}
+
+func TestBoundsDurationMalformed(t *testing.T) {
+	type config struct {
+		Timeout time.Duration `gonfig:"min=5"`
+	}
+
+	err := Bounds[config]()(&config{Timeout: time.Second})
+	if err == nil || !strings.Contains(err.Error(), `invalid min bound "5"`) {
+		t.Errorf("got error %v, want an invalid bound error", err)
+	}
+}
+
+func TestLenBounds(t *testing.T) {
+	type config struct {
+		Upstreams []string          `gonfig:"minlen=1,maxlen=3"`
+		Labels    map[string]string `gonfig:"maxlen=2"`
+		Name      string            `gonfig:"minlen=2"`
+	}
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr []string
+	}{
+		{"valid", config{Upstreams: []string{"a", "b"}, Labels: map[string]string{"env": "prod"}, Name: "app"}, nil},
+		{"at the bounds", config{Upstreams: []string{"a", "b", "c"}, Labels: map[string]string{"a": "", "b": ""}, Name: "ab"}, nil},
+		{"empty", config{Name: "app"}, []string{"field Upstreams: length 0 is less than the minimum of 1"}},
+		{"oversized", config{
+			Upstreams: []string{"a", "b", "c", "d"},
+			Labels:    map[string]string{"a": "", "b": "", "c": ""},
+			Name:      "a",
+		}, []string{
+			"field Upstreams: length 4 is greater than the maximum of 3",
+			"field Labels: length 3 is greater than the maximum of 2",
+			"field Name: length 1 is less than the minimum of 2",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LenBounds[config]()(&tt.c)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			for _, msg := range tt.wantErr {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("error %q does not contain %q", err, msg)
+				}
+			}
+		})
+	}
+}
+
+func TestLenBoundsMalformed(t *testing.T) {
+	type config struct {
+		Upstreams []string `gonfig:"minlen=one"`
+		Port      int      `gonfig:"maxlen=1"`
+	}
+
+	err := LenBounds[config]()(&config{})
+	if err == nil || !strings.Contains(err.Error(), `invalid minlen bound "one"`) || !strings.Contains(err.Error(), "not supported for type int") {
+		t.Errorf("got error %v, want a malformed and an unsupported bound", err)
+	}
+}
+
+func TestPositiveNonNegative(t *testing.T) {
+	type config struct {
+		Workers int           `gonfig:"positive,nonneg"`
+		Ratio   float64       `gonfig:"positive,nonneg"`
+		Timeout time.Duration `gonfig:"positive,nonneg"`
+		Limit   *int64        `gonfig:"positive,nonneg"`
+		Other   int
+	}
+
+	tests := []struct {
+		name            string
+		c               config
+		wantPositive    bool
+		wantNonNegative bool
+	}{
+		{"positive", config{Workers: 4, Ratio: 0.5, Timeout: time.Second}, false, false},
+		{"zero", config{}, true, false},
+		{"negative", config{Workers: -1, Ratio: -0.5, Timeout: -time.Second, Other: -1}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range []struct {
+				name     string
+				validate FinalizeFunc[*config]
+				wantErr  bool
+			}{
+				{"Positive", Positive[config](), tt.wantPositive},
+				{"NonNegative", NonNegative[config](), tt.wantNonNegative},
+			} {
+				err := v.validate(&tt.c)
+				if !v.wantErr {
+					if err != nil {
+						t.Errorf("got error %v from %s, want none", err, v.name)
+					}
+
+					continue
+				}
+
+				for _, field := range []string{"Workers", "Ratio", "Timeout"} {
+					if err == nil || !strings.Contains(err.Error(), "field "+field+":") {
+						t.Errorf("got error %v from %s, want it to name %s", err, v.name, field)
+					}
+				}
+
+				if strings.Contains(err.Error(), "Other") || strings.Contains(err.Error(), "Limit") {
+					t.Errorf("got error %v from %s, want untagged and nil fields skipped", err, v.name)
+				}
+			}
+		})
+	}
+
+	limit := int64(0)
+
+	err := Positive[config]()(&config{Workers: 1, Ratio: 1, Timeout: 1, Limit: &limit})
+	if err == nil || !strings.Contains(err.Error(), "Limit") {
+		t.Errorf("got error %v, want the zero value behind the pointer reported", err)
+	}
+}
+
+func TestPositiveUnsupported(t *testing.T) {
+	type config struct {
+		Name string `gonfig:"positive"`
+	}
+
+	err := Positive[config]()(&config{Name: "a"})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("got error %v, want an unsupported type error", err)
+	}
+}
+
+func TestPattern(t *testing.T) {
+	type bucket struct {
+		Name string `gonfig:"pattern=^[a-z0-9-]+$"`
+	}
+
+	type config struct {
+		User   string `gonfig:"required,pattern=^[a-z]{1,8}$"`
+		Bucket bucket
+		Backup *bucket
+		Other  string
+	}
+
+	validate := Pattern[config]()
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr []string
+	}{
+		{"matching", config{User: "alice", Bucket: bucket{Name: "logs-1"}, Other: "Any Value"}, nil},
+		{"non-matching", config{User: "Alice", Bucket: bucket{Name: "logs_1"}, Backup: &bucket{Name: "ok"}}, []string{
+			`field User: value "Alice" does not match pattern ^[a-z]{1,8}$`,
+			`field Bucket.Name: value "logs_1" does not match pattern ^[a-z0-9-]+$`,
+		}},
+		{"nested pointer", config{User: "bob", Bucket: bucket{Name: "a"}, Backup: &bucket{Name: "B"}}, []string{"field Backup.Name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.c)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			for _, msg := range tt.wantErr {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("error %q does not contain %q", err, msg)
+				}
+			}
+		})
+	}
+}
+
+func TestPatternInvalid(t *testing.T) {
+	type config struct {
+		Name string `gonfig:"pattern=^[a-z+$"`
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "invalid pattern for field Name") {
+			t.Errorf("got panic %v, want one for the invalid pattern", r)
+		}
+	}()
+
+	Pattern[config]()
+
+	t.Error("got no panic at setup")
+}
+
+func TestIPAddrCIDR(t *testing.T) {
+	type config struct {
+		Listen  string   `gonfig:"ip"`
+		Peers   []string `gonfig:"ip"`
+		Network string   `gonfig:"cidr"`
+		Allow   []string `gonfig:"cidr"`
+		Other   string
+	}
+
+	valid := config{
+		Listen:  "192.0.2.1",
+		Peers:   []string{"2001:db8::1", "198.51.100.7"},
+		Network: "192.0.2.0/24",
+		Allow:   []string{"10.0.0.0/8", "2001:db8::/32"},
+		Other:   "not an address",
+	}
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr []string
+	}{
+		{"valid", valid, nil},
+		{"empty", config{}, nil},
+		{"invalid", config{
+			Listen:  "192.0.2.256",
+			Peers:   []string{"2001:db8::1", "localhost"},
+			Network: "192.0.2.1",
+			Allow:   []string{"10.0.0.0/8", "10.0.0.0/33"},
+		}, []string{
+			`field Listen: invalid IP address "192.0.2.256"`,
+			`field Peers: invalid IP address "localhost"`,
+			"field Network:",
+			"field Allow:",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.Join(IPAddr[config]()(&tt.c), CIDR[config]()(&tt.c))
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("got no error")
+			}
+
+			for _, msg := range tt.wantErr {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("error %q does not contain %q", err, msg)
+				}
+			}
+
+			if strings.Contains(err.Error(), "2001:db8::1") || strings.Contains(err.Error(), "10.0.0.0/8") {
+				t.Errorf("error %q mentions a valid entry", err)
+			}
+		})
+	}
+}
+
+func TestWhen(t *testing.T) {
+	type config struct {
+		TLS      bool
+		CertFile string
+	}
+
+	validate := When(func(c *config) bool { return c.TLS }, func(c *config) error {
+		if c.CertFile == "" {
+			return errors.New("cert file is required when TLS is enabled")
+		}
+
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr bool
+	}{
+		{"disabled", config{}, false},
+		{"enabled without cert", config{TLS: true}, true},
+		{"enabled with cert", config{TLS: true, CertFile: "cert.pem"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnless(t *testing.T) {
+	type config struct {
+		Offline  bool
+		Upstream string
+	}
+
+	errUpstream := errors.New("upstream is required")
+
+	validate := Unless(func(c *config) bool { return c.Offline }, func(c *config) error {
+		if c.Upstream == "" {
+			return errUpstream
+		}
+
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr bool
+	}{
+		{"skipped", config{Offline: true}, false},
+		{"not skipped", config{}, true},
+		{"not skipped and valid", config{Upstream: "https://upstream"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.c)
+			if tt.wantErr != errors.Is(err, errUpstream) {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := Unless[*config](func(*config) bool { return false }, nil)(&config{})
+	if err != nil {
+		t.Errorf("got error %v from a nil validator, want none", err)
+	}
+}
+
+func TestField(t *testing.T) {
+	type database struct {
+		Port int
+	}
+
+	type config struct {
+		Port     int
+		Database database
+	}
+
+	errRange := errors.New("out of range")
+
+	port := func(p int) error {
+		if p < 1 || p > 65535 {
+			return errRange
+		}
+
+		return nil
+	}
+
+	validate := CombineValidators(
+		Field("port", func(c *config) int { return c.Port }, port),
+		Field("database.port", func(c *config) int { return c.Database.Port }, port),
+	)
+
+	err := validate(&config{Port: 8080, Database: database{Port: 0}})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	if got, want := err.Error(), "database.port: out of range"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+
+	if !errors.Is(err, errRange) {
+		t.Errorf("got error %v, want it to match errRange", err)
+	}
+
+	err = validate(&config{Port: 8080, Database: database{Port: 5432}})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	type config struct {
+		Password string
+		Token    string
+		CertFile string
+	}
+
+	set := []func(*config) bool{
+		func(c *config) bool { return c.Password != "" },
+		func(c *config) bool { return c.Token != "" },
+		func(c *config) bool { return c.CertFile != "" },
+	}
+
+	validators := map[string]FinalizeFunc[*config]{
+		"OneOf":      OneOf("auth methods", set...),
+		"AtMostOne":  AtMostOne("auth methods", set...),
+		"AtLeastOne": AtLeastOne("auth methods", set...),
+	}
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr map[string]bool
+	}{
+		{"none set", config{}, map[string]bool{"OneOf": true, "AtLeastOne": true}},
+		{"exactly one set", config{Token: "t"}, nil},
+		{"multiple set", config{Password: "p", CertFile: "cert.pem"}, map[string]bool{"OneOf": true, "AtMostOne": true}},
+	}
+
+	for _, tt := range tests {
+		for name, validate := range validators {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				err := validate(&tt.c)
+				if (err != nil) != tt.wantErr[name] {
+					t.Fatalf("got error %v, want error %t", err, tt.wantErr[name])
+				}
+
+				if err != nil && !strings.Contains(err.Error(), "auth methods") {
+					t.Errorf("error %q does not name the group", err)
+				}
+			})
+		}
+	}
+}
+
+func TestEnum(t *testing.T) {
+	type logLevel string
+
+	type config struct {
+		LogLevel logLevel
+	}
+
+	get := func(c *config) logLevel { return c.LogLevel }
+	allowed := []logLevel{"debug", "info", "warn", "error"}
+
+	tests := []struct {
+		name     string
+		validate FinalizeFunc[*config]
+		level    logLevel
+		wantErr  bool
+	}{
+		{"valid", Enum(get, allowed...), "info", false},
+		{"invalid", Enum(get, allowed...), "verbose", true},
+		{"wrong case", Enum(get, allowed...), "INFO", true},
+		{"case-insensitive", EnumFold(get, allowed...), "INFO", false},
+		{"case-insensitive invalid", EnumFold(get, allowed...), "VERBOSE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(&config{LogLevel: tt.level})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "debug, info, warn, error") {
+				t.Errorf("error %q does not list the allowed values", err)
+			}
+		})
+	}
+}
+
+func TestIntEnum(t *testing.T) {
+	type level int
+
+	const (
+		levelFast    level = 1
+		levelDefault level = 6
+		levelBest    level = 9
+	)
+
+	type config struct {
+		Compression level
+	}
+
+	validate := IntEnum(func(c *config) level { return c.Compression }, levelFast, levelDefault, levelBest)
+
+	err := validate(&config{Compression: levelDefault})
+	if err != nil {
+		t.Errorf("got error %v, want none", err)
+	}
+
+	err = validate(&config{Compression: 7})
+	if err == nil {
+		t.Fatal("got no error for an unknown code")
+	}
+
+	if want := "value 7 is not one of 1, 6, 9"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
+
+func TestVersionedValidator(t *testing.T) {
+	type config struct {
+		Version int
+		Name    string
+	}
+
+	v1 := func(c *config) error { return nil }
+	v2 := func(c *config) error {
+		if c.Name == "" {
+			return errors.New("name is required since version 2")
+		}
+
+		return nil
+	}
+
+	get := func(c *config) int { return c.Version }
+
+	tests := []struct {
+		name      string
+		byVersion map[int]FinalizeFunc[*config]
+		c         config
+		wantErr   string
+	}{
+		{"known version", map[int]FinalizeFunc[*config]{1: v1, 2: v2}, config{Version: 1}, ""},
+		{"known version invalid", map[int]FinalizeFunc[*config]{1: v1, 2: v2}, config{Version: 2}, "name is required"},
+		{"unknown version", map[int]FinalizeFunc[*config]{1: v1, 2: v2}, config{Version: 3}, "unsupported configuration version 3, supported are 1, 2"},
+		{"version zero without default", map[int]FinalizeFunc[*config]{1: v1, 2: v2}, config{}, "unsupported configuration version 0"},
+		{"version zero with default", map[int]FinalizeFunc[*config]{0: v1, 1: v1, 2: v2}, config{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VersionedValidator(get, tt.byVersion)(&tt.c)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	type server struct {
+		Name string
+		Port int
+	}
+
+	type config struct {
+		Servers []server
+	}
+
+	validate := UniqueBy(func(c *config) []server { return c.Servers }, func(s server) string { return s.Name })
+
+	err := validate(&config{Servers: []server{{"a", 1}, {"b", 2}, {"c", 3}}})
+	if err != nil {
+		t.Errorf("got error %v for unique names, want none", err)
+	}
+
+	err = validate(&config{Servers: []server{{"a", 1}, {"b", 2}, {"a", 3}, {"b", 4}, {"a", 5}}})
+	if err == nil {
+		t.Fatal("got no error for duplicate names")
+	}
+
+	if want := "duplicate key a at indices [0 2 4]\nduplicate key b at indices [1 3]"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
+
+func TestEachValue(t *testing.T) {
+	type server struct {
+		Addr string
+	}
+
+	type config struct {
+		Servers map[string]server
+	}
+
+	validate := EachValue(func(c *config) map[string]server { return c.Servers }, func(s server) error {
+		if s.Addr == "" {
+			return errors.New("missing address")
+		}
+
+		return nil
+	})
+
+	err := validate(&config{Servers: map[string]server{"primary": {Addr: ":80"}, "backup": {Addr: ":81"}}})
+	if err != nil {
+		t.Errorf("got error %v for valid entries, want none", err)
+	}
+
+	err = validate(&config{Servers: map[string]server{"primary": {}, "backup": {Addr: ":81"}, "canary": {}}})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+
+	if want := "[canary]: missing address\n[primary]: missing address"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+
+	err = validate(&config{})
+	if err != nil {
+		t.Errorf("got error %v for an empty map, want none", err)
+	}
+}
+
+func TestDistinctListeners(t *testing.T) {
+	type config struct {
+		HTTP    string  `gonfig:"listen"`
+		Metrics string  `gonfig:"listen"`
+		GRPC    *string `gonfig:"listen"`
+		Other   string
+	}
+
+	addr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		c       config
+		wantErr string
+	}{
+		{"distinct ports", config{HTTP: ":8080", Metrics: ":9090", GRPC: addr("127.0.0.1:50051"), Other: ":8080"}, ""},
+		{"distinct hosts", config{HTTP: "127.0.0.1:8080", Metrics: "192.0.2.1:8080"}, ""},
+		{"unset", config{HTTP: ":8080"}, ""},
+		{"duplicate port", config{HTTP: ":8080", Metrics: "0.0.0.0:8080"}, "fields HTTP and Metrics: addresses :8080 and 0.0.0.0:8080 overlap"},
+		{"unspecified overlaps specific", config{HTTP: "[::]:8080", GRPC: addr("localhost:8080")}, "fields HTTP and GRPC"},
+		{"same host", config{HTTP: "LOCALHOST:8080", Metrics: "localhost:08080"}, "fields HTTP and Metrics"},
+		{"IPv4-mapped", config{HTTP: "127.0.0.1:8080", Metrics: "[::ffff:127.0.0.1]:8080"}, "fields HTTP and Metrics"},
+		{"malformed", config{HTTP: "8080"}, "field HTTP:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DistinctListeners[config]()(&tt.c)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+//go:build gonfig_fsnotify
+
+package gonfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is the time to wait for further events before reloading.
+const watchDebounce = 100 * time.Millisecond
+
+// Watch watches the configuration file at path and re-reads it whenever it
+// changes, calling onChange with the configuration object and the error of
+// the reload, if any.
+//
+// Each reload unmarshals into a zero value of type T, which only replaces *c
+// if both unmarshaling and validation succeed. Otherwise *c keeps its previous
+// value and onChange receives the error. The parent directory is watched so
+// that atomic saves, which replace the file by renaming, are picked up, and
+// rapid successive events are coalesced into a single reload. Events that
+// leave the content unchanged, e.g. an editor touching the file or several
+// events for a single save, do not trigger onChange, since the Fingerprint
+// of the content is compared with that of the content read last. onChange is
+// called from a separate goroutine.
+//
+// The returned stop function ends watching and waits for a running reload to
+// finish. Only available with the gonfig_fsnotify build tag, which pulls in
+// github.com/fsnotify/fsnotify.
+func Watch[T any](path string, c *T, unmarshal UnmarshalFunc[*T], finalize FinalizeFunc[*T], onChange func(*T, error)) (stop func(), err error) {
+	path = filepath.Clean(path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	err = watcher.Add(filepath.Dir(path))
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	var last string
+
+	content, err := readFile(path, nil)
+	if err == nil {
+		last = Fingerprint(content)
+	}
+
+	reload := func() {
+		content, err := readFile(path, nil)
+		if err != nil {
+			onChange(c, fmt.Errorf("unable to read configuration file %s: %w", path, err))
+			return
+		}
+
+		sum := Fingerprint(content)
+		if sum == last {
+			return
+		}
+		last = sum
+
+		var next T
+
+		err = processConfig(path, content, &next, unmarshal, finalize, nil)
+		if err == nil {
+			*c = next
+		}
+
+		onChange(c, err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		timer := time.NewTimer(watchDebounce)
+		timer.Stop()
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+
+				timer.Reset(watchDebounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				onChange(c, err)
+			case <-timer.C:
+				_, err := os.Stat(path)
+				if err != nil {
+					// The file was renamed away, e.g. by an atomic save.
+					// Wait for it to be created again.
+					continue
+				}
+
+				reload()
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			watcher.Close()
+			wg.Wait()
+		})
+	}, nil
+}
@@ -0,0 +1,123 @@
+//go:build gonfig_fsnotify
+
+package gonfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watchResult struct {
+	c   testConfig
+	err error
+}
+
+// startWatch watches the configuration file at path and returns the channel
+// receiving the results passed to onChange.
+func startWatch(t *testing.T, path string) <-chan watchResult {
+	t.Helper()
+
+	var c testConfig
+
+	ch := make(chan watchResult, 16)
+
+	stop, err := Watch(path, &c, JSON[testConfig](), func(c *testConfig) error {
+		if c.Port < 0 {
+			return os.ErrInvalid
+		}
+
+		return nil
+	}, func(c *testConfig, err error) {
+		ch <- watchResult{*c, err}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+
+	return ch
+}
+
+// nextResult waits for the next result passed to onChange.
+func nextResult(t *testing.T, ch <-chan watchResult) watchResult {
+	t.Helper()
+
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("onChange was not called")
+		return watchResult{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "a"}`)
+
+	ch := startWatch(t, path)
+
+	writeFile(t, dir, "config.json", `{"Name": "b"}`)
+
+	r := nextResult(t, ch)
+	if r.err != nil || r.c.Name != "b" {
+		t.Fatalf("got %+v, want name b", r)
+	}
+
+	writeFile(t, dir, "config.json", `{"Name": "c", "Port": -1}`)
+
+	r = nextResult(t, ch)
+	if r.err == nil || r.c.Name != "b" {
+		t.Errorf("got %+v, want an error and the previous value", r)
+	}
+}
+
+func TestWatchAtomicSave(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "a"}`)
+
+	ch := startWatch(t, path)
+
+	tmp := writeFile(t, dir, "config.json.tmp", `{"Name": "renamed"}`)
+
+	err := os.Rename(tmp, filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := nextResult(t, ch)
+	if r.err != nil || r.c.Name != "renamed" {
+		t.Errorf("got %+v, want name renamed", r)
+	}
+}
+
+func TestWatchUnchangedContent(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "a"}`)
+
+	ch := startWatch(t, path)
+
+	writeFile(t, dir, "config.json", `{"Name": "a"}`)
+
+	select {
+	case r := <-ch:
+		t.Fatalf("got onChange with %+v for identical content", r)
+	case <-time.After(3 * watchDebounce):
+	}
+
+	writeFile(t, dir, "config.json", `{"Name": "b"}`)
+	writeFile(t, dir, "config.json", `{"Name": "b"}`)
+
+	r := nextResult(t, ch)
+	if r.err != nil || r.c.Name != "b" {
+		t.Fatalf("got %+v, want name b", r)
+	}
+
+	select {
+	case r := <-ch:
+		t.Errorf("got a second onChange with %+v for a single change", r)
+	case <-time.After(3 * watchDebounce):
+	}
+}
@@ -0,0 +1,85 @@
+package gonfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WriteConfig marshals the configuration object and atomically writes it to
+// the configuration file at path.
+//
+// The content is written to a temporary file in the same directory, synced
+// to disk, and renamed into place, so that readers never observe a partially
+// written file and an existing file is left untouched on failure. Missing
+// parent directories are created. The permissions of an existing file are
+// preserved, while new files are created with mode 0600.
+//
+// An exclusive lock on the lock file of the configuration file is held while
+// writing, as described for ReadConfigLocked, so that concurrent writers are
+// serialized and readers using ReadConfigLocked wait for the write to finish.
+func WriteConfig[T any](path string, c *T, marshal MarshalFunc[*T]) error {
+	content, err := marshal(c)
+	if err != nil {
+		return fmt.Errorf("unable to marshal configuration file %s: %w", path, err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("unable to create directory for configuration file %s: %w", path, err)
+	}
+
+	release, err := lockConfig(path, true)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var mode fs.FileMode = 0o600
+
+	info, err := os.Stat(path)
+	if err == nil {
+		mode = info.Mode().Perm()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not stat configuration file %s: %w", path, err)
+	}
+
+	err = writeAtomic(path, content, mode)
+	if err != nil {
+		return fmt.Errorf("unable to write configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeAtomic writes content to a temporary file next to path and renames it
+// to path.
+func writeAtomic(path string, content []byte, mode fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	// Only fails once the file has been renamed into place.
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	closeErr := tmp.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,142 @@
+package gonfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWriteConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dir", "config.json")
+
+	c := testConfig{Name: "app", Port: 8080}
+
+	err := WriteConfig(path, &c, MarshalJSON[testConfig]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "{\n  \"Name\": \"app\",\n  \"Port\": 8080\n}\n"
+	if string(content) != want {
+		t.Errorf("got content %q, want %q", content, want)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("got mode %v for a new file, want 0600", perm)
+	}
+}
+
+func TestWriteConfigPreservesMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissions are not supported")
+	}
+
+	path := writeFile(t, t.TempDir(), "config.json", `{}`)
+
+	err := os.Chmod(path, 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = WriteConfig(path, &testConfig{Name: "app"}, MarshalJSON[testConfig]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o640 {
+		t.Errorf("got mode %v, want the existing mode 0640", perm)
+	}
+}
+
+func TestWriteConfigMarshalError(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"Name": "old"}`)
+
+	err := WriteConfig(path, &testConfig{Name: "new"}, func(*testConfig) ([]byte, error) {
+		return nil, errors.New("marshal failed")
+	})
+	if err == nil || !strings.Contains(err.Error(), "marshal failed") {
+		t.Fatalf("got error %v, want the marshal error", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != `{"Name": "old"}` {
+		t.Errorf("got content %q, want the existing file untouched", content)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		if entry.Name() != "config.json" && entry.Name() != "config.json.lock" {
+			t.Errorf("found leftover file %s", entry.Name())
+		}
+	}
+}
+
+// testRoundTrip reads the configuration file at path, mutates it, writes it
+// back, and checks that reading it again yields the mutated configuration.
+func testRoundTrip[T comparable](t *testing.T, path string, unmarshal UnmarshalFunc[*T], marshal MarshalFunc[*T], mutate func(*T)) {
+	t.Helper()
+
+	var c T
+
+	_, err := ReadConfig(path, nil, &c, unmarshal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mutate(&c)
+
+	err = WriteConfig(path, &c, marshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got T
+
+	_, err = ReadConfig(path, nil, &got, unmarshal, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != c {
+		t.Errorf("got %+v, want %+v", got, c)
+	}
+}
+
+func TestRoundTripJSON(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"Name": "app", "Port": 80}`)
+
+	testRoundTrip(t, path, JSON[testConfig](), MarshalJSON[testConfig](), func(c *testConfig) {
+		c.Port = 8080
+	})
+}
@@ -0,0 +1,87 @@
+//go:build gonfig_yaml
+
+package gonfig
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	RegisterDecoder(".yaml", yaml.Unmarshal)
+	RegisterDecoder(".yml", yaml.Unmarshal)
+	positionFuncs = append(positionFuncs, yamlPosition)
+}
+
+// yamlLinePattern matches the line reported in YAML errors.
+var yamlLinePattern = regexp.MustCompile(`\bline (\d+):`)
+
+// yamlPosition extracts the line from YAML errors, which only report it as
+// part of their message.
+func yamlPosition(err error, _ []byte) (int, int, bool) {
+	m := yamlLinePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, 0, false
+	}
+
+	line, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return line, 0, true
+}
+
+// YAML returns an UnmarshalFunc that decodes YAML content.
+//
+// Only available with the gonfig_yaml build tag, which pulls in
+// gopkg.in/yaml.v3.
+func YAML[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		return yaml.Unmarshal(content, c)
+	}
+}
+
+// YAMLStrict returns an UnmarshalFunc that decodes YAML content and rejects
+// keys that do not correspond to a field of the configuration object. The
+// error lists all unexpected keys.
+//
+// Only available with the gonfig_yaml build tag.
+func YAMLStrict[T any]() UnmarshalFunc[*T] {
+	return func(content []byte, c *T) error {
+		dec := yaml.NewDecoder(bytes.NewReader(content))
+		dec.KnownFields(true)
+
+		err := dec.Decode(c)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+// MarshalYAML returns a MarshalFunc that encodes the configuration object as
+// YAML.
+//
+// Only available with the gonfig_yaml build tag.
+func MarshalYAML[T any]() MarshalFunc[*T] {
+	return func(c *T) ([]byte, error) {
+		return yaml.Marshal(c)
+	}
+}
+
+// YAMLDecoder returns a NewDecoderFunc for streams of YAML documents
+// separated by "---".
+//
+// Only available with the gonfig_yaml build tag.
+func YAMLDecoder() NewDecoderFunc {
+	return func(r io.Reader) Decoder {
+		return yaml.NewDecoder(r)
+	}
+}
@@ -0,0 +1,157 @@
+//go:build gonfig_yaml
+
+package gonfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type yamlConfig struct {
+	Name   string `yaml:"name"`
+	Server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"server"`
+	Tags []string `yaml:"tags"`
+}
+
+func TestYAML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", `name: app
+server:
+  host: localhost
+  port: 8080
+tags:
+  - a
+  - b
+`)
+
+	var c yamlConfig
+
+	_, err := ReadConfig(path, nil, &c, YAML[yamlConfig](), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "app" || c.Server.Host != "localhost" || c.Server.Port != 8080 {
+		t.Errorf("got %+v", c)
+	}
+
+	if !reflect.DeepEqual(c.Tags, []string{"a", "b"}) {
+		t.Errorf("got tags %q, want [a b]", c.Tags)
+	}
+}
+
+func TestYAMLMalformed(t *testing.T) {
+	var c yamlConfig
+
+	err := YAML[yamlConfig]()([]byte("name: [unterminated\n"), &c)
+	if err == nil {
+		t.Error("got no error for malformed YAML")
+	}
+}
+
+func TestReadConfigAutoYAML(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, dir, name, "name: yaml\n")
+
+			var c struct {
+				Name string `yaml:"name"`
+			}
+
+			_, err := ReadConfigAuto(path, nil, &c, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Name != "yaml" {
+				t.Errorf("got name %q, want yaml", c.Name)
+			}
+		})
+	}
+}
+
+func TestRoundTripYAML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "name: app\nport: 80\n")
+
+	testRoundTrip(t, path, YAML[testConfig](), MarshalYAML[testConfig](), func(c *testConfig) {
+		c.Port = 8080
+	})
+}
+
+func TestYAMLPosition(t *testing.T) {
+	var c yamlConfig
+
+	err := ReadConfigBytes([]byte("name: app\nserver:\n  port: [1\n"), &c, YAML[yamlConfig](), nil)
+
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Fatalf("got error %v, want an *UnmarshalError", err)
+	}
+
+	if unmarshalErr.Line == 0 {
+		t.Errorf("got no line for error %v", err)
+	}
+}
+
+func TestYAMLStrict(t *testing.T) {
+	var c yamlConfig
+
+	err := YAMLStrict[yamlConfig]()([]byte("name: app\ndatabse_url: x\n"), &c)
+	if err == nil || !strings.Contains(err.Error(), "databse_url") {
+		t.Errorf("got error %v, want one naming the unknown key", err)
+	}
+
+	err = YAMLStrict[yamlConfig]()([]byte("name: app\n"), &c)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = YAMLStrict[yamlConfig]()(nil, &c)
+	if err != nil {
+		t.Errorf("got error %v for empty content, want none", err)
+	}
+}
+
+func TestAllowEmptyYAML(t *testing.T) {
+	testAllowEmpty(t, map[string]UnmarshalFunc[*defaultsConfig]{
+		"YAML":       YAML[defaultsConfig](),
+		"YAMLStrict": YAMLStrict[defaultsConfig](),
+	})
+}
+
+func TestReadConfigAllYAML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "jobs.yaml", `name: a
+tags: [x]
+---
+name: b
+---
+name: c
+server:
+  port: 3
+`)
+
+	all, err := ReadConfigAll[yamlConfig](path, YAMLDecoder(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 3 {
+		t.Fatalf("got %d documents, want 3", len(all))
+	}
+
+	for i, name := range []string{"a", "b", "c"} {
+		if all[i].Name != name {
+			t.Errorf("got name %q for document %d, want %q", all[i].Name, i, name)
+		}
+	}
+
+	if all[2].Server.Port != 3 || len(all[1].Tags) != 0 {
+		t.Errorf("got %+v, want the documents decoded independently", all)
+	}
+}